@@ -0,0 +1,154 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+)
+
+// AggregationKind is the server-side aggregation to compute.
+type AggregationKind int
+
+const (
+	AggregateCount AggregationKind = iota + 1
+	AggregateSum
+	AggregateAverage
+)
+
+// Aggregation is a single aggregation of a query, reported under Alias.
+type Aggregation struct {
+	Alias string
+	Kind  AggregationKind
+	Field string
+}
+
+// CountOf counts the documents.
+func CountOf(alias string) Aggregation {
+	return Aggregation{Alias: alias, Kind: AggregateCount}
+}
+
+// SumOf sums the numeric values of field.
+func SumOf(field, alias string) Aggregation {
+	return Aggregation{Alias: alias, Kind: AggregateSum, Field: field}
+}
+
+// AverageOf averages the numeric values of field.
+func AverageOf(field, alias string) Aggregation {
+	return Aggregation{Alias: alias, Kind: AggregateAverage, Field: field}
+}
+
+// AggregationResult maps each alias to an int64, a float64, or nil when
+// there was nothing to average.
+type AggregationResult map[string]any
+
+// Int64 returns the value of alias as an int64.
+func (r AggregationResult) Int64(alias string) int64 {
+	switch v := r[alias].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// Float64 returns the value of alias as a float64.
+func (r AggregationResult) Float64(alias string) float64 {
+	switch v := r[alias].(type) {
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func (i *inner) Count(ctx context.Context, q Query) (int64, error) {
+	res, err := i.Aggregate(ctx, q, CountOf("count"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Int64("count"), nil
+}
+
+func (i *inner) Sum(ctx context.Context, q Query, field string) (float64, error) {
+	res, err := i.Aggregate(ctx, q, SumOf(field, "sum"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Float64("sum"), nil
+}
+
+func (i *inner) Average(ctx context.Context, q Query, field string) (float64, error) {
+	res, err := i.Aggregate(ctx, q, AverageOf(field, "average"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Float64("average"), nil
+}
+
+func (i *inner) Aggregate(ctx context.Context, q Query, aggregations ...Aggregation) (AggregationResult, error) {
+	ctx, span := tracer.Start(ctx, "Aggregate")
+	defer span.End()
+
+	fq := toFirestoreQuery(i.client, q)
+	aq := fq.NewAggregationQuery()
+	for _, a := range aggregations {
+		switch a.Kind {
+		case AggregateCount:
+			aq = aq.WithCount(a.Alias)
+		case AggregateSum:
+			aq = aq.WithSum(a.Field, a.Alias)
+		case AggregateAverage:
+			aq = aq.WithAvg(a.Field, a.Alias)
+		}
+	}
+	res, err := aq.Get(ctx)
+	if err != nil {
+		return nil, newOpError("Aggregate", q.Spec().Collection, err)
+	}
+	return fromFirestoreAggregation(res), nil
+}
+
+func fromFirestoreAggregation(res firestore.AggregationResult) AggregationResult {
+	ret := AggregationResult{}
+	for alias, v := range res {
+		pv, ok := v.(*pb.Value)
+		if !ok {
+			ret[alias] = v
+			continue
+		}
+		switch t := pv.GetValueType().(type) {
+		case *pb.Value_IntegerValue:
+			ret[alias] = t.IntegerValue
+		case *pb.Value_DoubleValue:
+			ret[alias] = t.DoubleValue
+		default:
+			ret[alias] = nil
+		}
+	}
+	return ret
+}
@@ -0,0 +1,192 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"cloud.google.com/go/firestore"
+)
+
+// MaxBatchWrites is the number of writes Firestore accepts in one commit.
+const MaxBatchWrites = 500
+
+// ErrBatchTooLarge is returned when a batch exceeds MaxBatchWrites.
+var ErrBatchTooLarge = fmt.Errorf("cloudfirestore: batch exceeds %d writes", MaxBatchWrites)
+
+// ErrBatchCommitted is returned when a batch is used after Commit.
+var ErrBatchCommitted = errors.New("cloudfirestore: batch already committed")
+
+// Batch queues writes and commits them atomically. Unlike a transaction it
+// performs no reads, so the writes are applied blindly.
+type Batch interface {
+	// Create queues the creation of Pathable data
+	Create(context.Context, any) error
+	// Set queues the write of Pathable data
+	Set(context.Context, any) error
+	// Update queues the update of fields of Pathable data
+	Update(context.Context, Pathable, ...FieldUpdate) error
+	// Delete queues the deletion of Pathable data
+	Delete(context.Context, any) error
+	// Commit applies all queued writes, or none of them.
+	Commit(context.Context) error
+}
+
+type batchWrite struct {
+	op    string
+	path  string
+	apply func(*firestore.Transaction) error
+}
+
+type innerBatch struct {
+	client *firestore.Client
+
+	mu        sync.Mutex
+	writes    []batchWrite
+	committed bool
+}
+
+func (i *inner) Batch() Batch {
+	return &innerBatch{
+		client: i.client,
+	}
+}
+
+func (b *innerBatch) Create(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Create", "", ErrNotPathable)
+	}
+	AllocateID(ctx, data)
+	if err := BeforeCreate(ctx, data); err != nil {
+		return newOpError("Create", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Create", path, err)
+	}
+	if err := Validate(ctx, data); err != nil {
+		return newOpError("Create", path, err)
+	}
+	return b.add(batchWrite{op: "Create", path: path, apply: func(t *firestore.Transaction) error {
+		return t.Create(b.client.Doc(path), data)
+	}})
+}
+
+func (b *innerBatch) Set(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Set", "", ErrNotPathable)
+	}
+	if err := BeforeSet(ctx, data); err != nil {
+		return newOpError("Set", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Set", path, err)
+	}
+	if err := Validate(ctx, data); err != nil {
+		return newOpError("Set", path, err)
+	}
+	return b.add(batchWrite{op: "Set", path: path, apply: func(t *firestore.Transaction) error {
+		return t.Set(b.client.Doc(path), data)
+	}})
+}
+
+func (b *innerBatch) Update(ctx context.Context, data Pathable, updates ...FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return newOpError("Update", path, err)
+	}
+	if err := ValidateUpdates(data, updates); err != nil {
+		return newOpError("Update", path, err)
+	}
+	fu, err := toFirestoreUpdates(updates)
+	if err != nil {
+		return newOpError("Update", path, err)
+	}
+	return b.add(batchWrite{op: "Update", path: path, apply: func(t *firestore.Transaction) error {
+		return t.Update(b.client.Doc(path), fu)
+	}})
+}
+
+func (b *innerBatch) Delete(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Delete", "", ErrNotPathable)
+	}
+	if err := BeforeDelete(ctx, data); err != nil {
+		return newOpError("Delete", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Delete", path, err)
+	}
+	return b.add(batchWrite{op: "Delete", path: path, apply: func(t *firestore.Transaction) error {
+		return t.Delete(b.client.Doc(path))
+	}})
+}
+
+func (b *innerBatch) add(w batchWrite) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.committed {
+		return newOpError(w.op, w.path, ErrBatchCommitted)
+	}
+	if len(b.writes) >= MaxBatchWrites {
+		return newOpError(w.op, w.path, ErrBatchTooLarge)
+	}
+	b.writes = append(b.writes, w)
+	return nil
+}
+
+func (b *innerBatch) Commit(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "Batch/Commit")
+	defer span.End()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.committed {
+		return newOpError("Commit", "", ErrBatchCommitted)
+	}
+	b.committed = true
+	if len(b.writes) == 0 {
+		return nil
+	}
+
+	// A transaction without reads, replacing the deprecated WriteBatch,
+	// takes a BeginTransaction and a Commit request. The SDK retries it
+	// when aborted, which is safe as nothing was read.
+	err := b.client.RunTransaction(ctx, func(_ context.Context, t *firestore.Transaction) error {
+		for _, w := range b.writes {
+			if err := w.apply(t); err != nil {
+				return newOpError(w.op, w.path, err)
+			}
+		}
+		return nil
+	})
+	return newOpError("Commit", "", err)
+}
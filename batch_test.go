@@ -0,0 +1,59 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+func TestBatchLimits(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(t, newFakeFirestore(0))
+
+	b := c.Batch()
+	for idx := range cloudfirestore.MaxBatchWrites {
+		if err := b.Set(ctx, &bulkItem{ID: fmt.Sprint(idx)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Delete(ctx, &bulkItem{ID: "extra"}); !errors.Is(err, cloudfirestore.ErrBatchTooLarge) {
+		t.Errorf("Delete = %v, want ErrBatchTooLarge", err)
+	}
+
+	// An empty batch commits without a request.
+	b = c.Batch()
+	if err := b.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set(ctx, &bulkItem{ID: "a"}); !errors.Is(err, cloudfirestore.ErrBatchCommitted) {
+		t.Errorf("Set after Commit = %v, want ErrBatchCommitted", err)
+	}
+	if err := b.Commit(ctx); !errors.Is(err, cloudfirestore.ErrBatchCommitted) {
+		t.Errorf("second Commit = %v, want ErrBatchCommitted", err)
+	}
+}
@@ -0,0 +1,270 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// The 500/50/5 rule: start at 500 writes per second and grow by 50%
+	// every 5 minutes.
+	bulkStartRate    = 500
+	bulkRampFactor   = 1.5
+	bulkRampInterval = 5 * time.Minute
+)
+
+// BulkWriter queues independent writes and sends them in parallel. Writes
+// are not atomic; each one succeeds or fails on its own.
+type BulkWriter interface {
+	// Create queues the creation of Pathable data
+	Create(context.Context, any) error
+	// Set queues the write of Pathable data
+	Set(context.Context, any) error
+	// Update queues the update of fields of Pathable data
+	Update(context.Context, Pathable, ...FieldUpdate) error
+	// Delete queues the deletion of Pathable data
+	Delete(context.Context, any) error
+	// Flush waits for the queued writes and reports their results. The SDK
+	// retries retryable failures with backoff. The returned error joins
+	// every failure.
+	Flush(context.Context) (BulkSummary, error)
+	// End flushes and closes the writer.
+	End(context.Context) (BulkSummary, error)
+}
+
+// BulkSummary reports the results of the writes of one Flush.
+type BulkSummary struct {
+	Succeeded int
+	Failed    int
+	// Errors maps the path of each failed write to its error.
+	Errors map[string]error
+}
+
+func (s *BulkSummary) add(path string, err error) {
+	if err == nil {
+		s.Succeeded++
+		return
+	}
+	s.Failed++
+	if s.Errors == nil {
+		s.Errors = map[string]error{}
+	}
+	s.Errors[path] = err
+}
+
+func (s BulkSummary) err() error {
+	errs := make([]error, 0, len(s.Errors))
+	for _, err := range s.Errors {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// bulkRamp limits writes following the 500/50/5 rule.
+type bulkRamp struct {
+	start   time.Time
+	limiter *rate.Limiter
+}
+
+func newBulkRamp() *bulkRamp {
+	return &bulkRamp{
+		start:   time.Now(),
+		limiter: rate.NewLimiter(bulkStartRate, bulkStartRate),
+	}
+}
+
+func (r *bulkRamp) wait(ctx context.Context) error {
+	steps := math.Floor(float64(time.Since(r.start)) / float64(bulkRampInterval))
+	limit := bulkStartRate * math.Pow(bulkRampFactor, steps)
+	if rate.Limit(limit) != r.limiter.Limit() {
+		r.limiter.SetLimit(rate.Limit(limit))
+		r.limiter.SetBurst(int(limit))
+	}
+	return r.limiter.Wait(ctx)
+}
+
+type bulkWrite struct {
+	op    string
+	path  string
+	apply func(*firestore.BulkWriter) (*firestore.BulkWriterJob, error)
+	job   *firestore.BulkWriterJob
+}
+
+type innerBulk struct {
+	client *firestore.Client
+	ctx    context.Context
+	ramp   *bulkRamp
+
+	mu      sync.Mutex
+	bw      *firestore.BulkWriter
+	pending []*bulkWrite
+	closed  bool
+}
+
+func (i *inner) Bulk(ctx context.Context) BulkWriter {
+	return &innerBulk{
+		client: i.client,
+		ctx:    ctx,
+		ramp:   newBulkRamp(),
+		bw:     i.client.BulkWriter(ctx),
+	}
+}
+
+func (b *innerBulk) Create(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Create", "", ErrNotPathable)
+	}
+	AllocateID(ctx, data)
+	if err := BeforeCreate(ctx, data); err != nil {
+		return newOpError("Create", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Create", path, err)
+	}
+	if err := Validate(ctx, data); err != nil {
+		return newOpError("Create", path, err)
+	}
+	return b.enqueue(ctx, &bulkWrite{op: "Create", path: path, apply: func(bw *firestore.BulkWriter) (*firestore.BulkWriterJob, error) {
+		return bw.Create(b.client.Doc(path), data)
+	}})
+}
+
+func (b *innerBulk) Set(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Set", "", ErrNotPathable)
+	}
+	if err := BeforeSet(ctx, data); err != nil {
+		return newOpError("Set", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Set", path, err)
+	}
+	if err := Validate(ctx, data); err != nil {
+		return newOpError("Set", path, err)
+	}
+	return b.enqueue(ctx, &bulkWrite{op: "Set", path: path, apply: func(bw *firestore.BulkWriter) (*firestore.BulkWriterJob, error) {
+		return bw.Set(b.client.Doc(path), data)
+	}})
+}
+
+func (b *innerBulk) Update(ctx context.Context, data Pathable, updates ...FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return newOpError("Update", path, err)
+	}
+	if err := ValidateUpdates(data, updates); err != nil {
+		return newOpError("Update", path, err)
+	}
+	fu, err := toFirestoreUpdates(updates)
+	if err != nil {
+		return newOpError("Update", path, err)
+	}
+	return b.enqueue(ctx, &bulkWrite{op: "Update", path: path, apply: func(bw *firestore.BulkWriter) (*firestore.BulkWriterJob, error) {
+		return bw.Update(b.client.Doc(path), fu)
+	}})
+}
+
+func (b *innerBulk) Delete(ctx context.Context, data any) error {
+	p, ok := data.(Pathable)
+	if !ok {
+		return newOpError("Delete", "", ErrNotPathable)
+	}
+	if err := BeforeDelete(ctx, data); err != nil {
+		return newOpError("Delete", p.Path(ctx), err)
+	}
+	path := p.Path(ctx)
+	if err := ValidatePath(p, path); err != nil {
+		return newOpError("Delete", path, err)
+	}
+	return b.enqueue(ctx, &bulkWrite{op: "Delete", path: path, apply: func(bw *firestore.BulkWriter) (*firestore.BulkWriterJob, error) {
+		return bw.Delete(b.client.Doc(path))
+	}})
+}
+
+func (b *innerBulk) enqueue(ctx context.Context, w *bulkWrite) error {
+	if err := b.ramp.wait(ctx); err != nil {
+		return newOpError(w.op, w.path, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return newOpError(w.op, w.path, errors.New("cloudfirestore: bulk writer is closed"))
+	}
+	job, err := w.apply(b.bw)
+	if err != nil {
+		return newOpError(w.op, w.path, err)
+	}
+	w.job = job
+	b.pending = append(b.pending, w)
+	return nil
+}
+
+func (b *innerBulk) Flush(ctx context.Context) (BulkSummary, error) {
+	return b.flush(ctx, false)
+}
+
+func (b *innerBulk) End(ctx context.Context) (BulkSummary, error) {
+	return b.flush(ctx, true)
+}
+
+func (b *innerBulk) flush(ctx context.Context, end bool) (BulkSummary, error) {
+	_, span := tracer.Start(ctx, "Bulk/Flush")
+	defer span.End()
+
+	var summary BulkSummary
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return summary, nil
+	}
+	// A BulkWriter accepts one write per document, so each flush starts a
+	// new one. Producers keep queuing into it while this one drains.
+	writes, bw := b.pending, b.bw
+	b.pending = nil
+	if end {
+		b.closed = true
+	} else {
+		b.bw = b.client.BulkWriter(b.ctx)
+	}
+	b.mu.Unlock()
+
+	bw.End()
+	for _, w := range writes {
+		_, err := w.job.Results()
+		summary.add(w.path, newOpError(w.op, w.path, err))
+	}
+	return summary, summary.err()
+}
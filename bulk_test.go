@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"google.golang.org/grpc/codes"
+)
+
+type bulkItem struct {
+	ID    string `firestore:"-"`
+	Count int
+}
+
+func (b *bulkItem) Path(context.Context) string {
+	return "bulkItems/" + b.ID
+}
+
+func TestBulkFlush(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeFirestore(0)
+	f.fail = func(name string, attempt int) codes.Code {
+		switch {
+		case strings.HasSuffix(name, "/bad"):
+			return codes.InvalidArgument
+		case strings.HasSuffix(name, "/flaky") && attempt == 1:
+			return codes.Unavailable
+		}
+		return codes.OK
+	}
+	c := newFakeClient(t, f)
+	bw := c.Bulk(ctx)
+
+	for idx := range 20 {
+		if err := bw.Set(ctx, &bulkItem{ID: fmt.Sprint(idx)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"bad", "flaky"} {
+		if err := bw.Set(ctx, &bulkItem{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	summary, err := bw.Flush(ctx)
+	if summary.Succeeded != 21 || summary.Failed != 1 {
+		t.Errorf("summary = %+v, want 21 succeeded and 1 failed", summary)
+	}
+	var oe *cloudfirestore.OpError
+	if !errors.As(summary.Errors["bulkItems/bad"], &oe) || !errors.As(err, &oe) {
+		t.Errorf("Flush = %v, errors %v, want bulkItems/bad failed", err, summary.Errors)
+	}
+	name := f.database + "/documents/bulkItems/"
+	if n := f.writes[name+"flaky"]; n != 2 {
+		t.Errorf("flaky write sent %d times, want 2", n)
+	}
+	if n := f.writes[name+"bad"]; n != 1 {
+		t.Errorf("bad write sent %d times, want 1", n)
+	}
+
+	// A flush starts a new writer, which accepts the same documents again.
+	if err := bw.Set(ctx, &bulkItem{ID: "0", Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	summary, err = bw.End(ctx)
+	if err != nil || summary.Succeeded != 1 {
+		t.Errorf("End = %+v, %v, want 1 succeeded", summary, err)
+	}
+	if n := f.writes[name+"0"]; n != 2 {
+		t.Errorf("document 0 written %d times, want 2", n)
+	}
+	if err := bw.Set(ctx, &bulkItem{ID: "1"}); err == nil {
+		t.Error("Set after End succeeded")
+	}
+	if summary, err := bw.Flush(ctx); err != nil || summary.Succeeded != 0 {
+		t.Errorf("Flush after End = %+v, %v, want nothing", summary, err)
+	}
+}
@@ -24,20 +24,27 @@ package cloudfirestore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var tracer = otel.Tracer("cloudfirestore")
 
 type inner struct {
 	client *firestore.Client
+	// newClient opens another client like client, for reads at a fixed
+	// time, which the SDK only applies client-wide.
+	newClient func(context.Context) (*firestore.Client, error)
 }
 
 func New(ctx context.Context, opts ...option.ClientOption) (CloudFirestore, error) {
@@ -47,6 +54,9 @@ func New(ctx context.Context, opts ...option.ClientOption) (CloudFirestore, erro
 	}
 	return &inner{
 		client: client,
+		newClient: func(ctx context.Context) (*firestore.Client, error) {
+			return firestore.NewClient(ctx, firestore.DetectProjectID, opts...)
+		},
 	}, nil
 }
 
@@ -57,6 +67,9 @@ func NewWithDatabase(ctx context.Context, databaseID string, opts ...option.Clie
 	}
 	return &inner{
 		client: client,
+		newClient: func(ctx context.Context) (*firestore.Client, error) {
+			return firestore.NewClientWithDatabase(ctx, firestore.DetectProjectID, databaseID, opts...)
+		},
 	}, nil
 }
 
@@ -66,10 +79,25 @@ func (i *inner) Create(ctx context.Context, data any) error {
 
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		_, err := i.client.Doc(p.Path(ctx)).Create(ctx, data)
-		return err
+		AllocateID(ctx, data)
+		if err := BeforeCreate(ctx, data); err != nil {
+			return newOpError("Create", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Create", path, err)
+		}
+		if err := Validate(ctx, data); err != nil {
+			return newOpError("Create", path, err)
+		}
+		wr, err := i.client.Doc(path).Create(ctx, data)
+		if err != nil {
+			return newOpError("Create", path, err)
+		}
+		SetVersion(data, wr.UpdateTime)
+		return nil
 	}
-	return fmt.Errorf("not implement Pathable")
+	return newOpError("Create", "", ErrNotPathable)
 }
 
 func (i *inner) Delete(ctx context.Context, data any) error {
@@ -78,10 +106,21 @@ func (i *inner) Delete(ctx context.Context, data any) error {
 
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		_, err := i.client.Doc(p.Path(ctx)).Delete(ctx)
-		return err
+		if err := BeforeDelete(ctx, data); err != nil {
+			return newOpError("Delete", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Delete", path, err)
+		}
+		_, err := i.client.Doc(path).Delete(ctx, preconditions(data)...)
+		if err != nil {
+			return newOpError("Delete", path, conflict(err))
+		}
+		SetVersion(data, time.Time{})
+		return nil
 	}
-	return fmt.Errorf("not implement Pathable")
+	return newOpError("Delete", "", ErrNotPathable)
 }
 
 func (i *inner) Get(ctx context.Context, data any) error {
@@ -90,13 +129,57 @@ func (i *inner) Get(ctx context.Context, data any) error {
 
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		ss, err := i.client.Doc(p.Path(ctx)).Get(ctx)
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Get", path, err)
+		}
+		ss, err := i.client.Doc(path).Get(ctx)
 		if err != nil {
-			return err
+			return newOpError("Get", path, err)
+		}
+		return newOpError("Get", path, snapshotTo(ctx, ss, data))
+	}
+	return newOpError("Get", "", ErrNotPathable)
+}
+
+func (i *inner) GetAll(ctx context.Context, data []Pathable) ([]error, error) {
+	ctx, span := tracer.Start(ctx, "GetAll")
+	defer span.End()
+
+	paths, refs, err := docRefs(ctx, i.client, data)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := i.client.GetAll(ctx, refs)
+	if err != nil {
+		return nil, newOpError("GetAll", "", err)
+	}
+	return dataToAll(ctx, data, paths, snapshots), nil
+}
+
+func docRefs(ctx context.Context, client *firestore.Client, data []Pathable) ([]string, []*firestore.DocumentRef, error) {
+	paths := make([]string, len(data))
+	refs := make([]*firestore.DocumentRef, len(data))
+	for idx, d := range data {
+		paths[idx] = d.Path(ctx)
+		if err := ValidatePath(d, paths[idx]); err != nil {
+			return nil, nil, newOpError("GetAll", paths[idx], err)
 		}
-		return ss.DataTo(data)
+		refs[idx] = client.Doc(paths[idx])
 	}
-	return fmt.Errorf("not implement Pathable")
+	return paths, refs, nil
+}
+
+func dataToAll(ctx context.Context, data []Pathable, paths []string, snapshots []*firestore.DocumentSnapshot) []error {
+	errs := make([]error, len(data))
+	for idx, s := range snapshots {
+		if !s.Exists() {
+			errs[idx] = newOpError("GetAll", paths[idx], ErrNotFound)
+			continue
+		}
+		errs[idx] = newOpError("GetAll", paths[idx], snapshotTo(ctx, s, data[idx]))
+	}
+	return errs
 }
 
 func (i *inner) Set(ctx context.Context, data any) error {
@@ -105,41 +188,149 @@ func (i *inner) Set(ctx context.Context, data any) error {
 
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		_, err := i.client.Doc(p.Path(ctx)).Set(ctx, data)
+		if err := BeforeSet(ctx, data); err != nil {
+			return newOpError("Set", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Set", path, err)
+		}
+		if err := Validate(ctx, data); err != nil {
+			return newOpError("Set", path, err)
+		}
+		if _, ok := VersionOf(data); ok {
+			return newOpError("Set", path, i.setVersioned(ctx, path, data))
+		}
+		_, err := i.client.Doc(path).Set(ctx, data)
+		return newOpError("Set", path, err)
+	}
+	return newOpError("Set", "", ErrNotPathable)
+}
+
+// setVersioned compares the version of data with the stored document and
+// writes it in one transaction.
+func (i *inner) setVersioned(ctx context.Context, path string, data any) error {
+	ref := i.client.Doc(path)
+	var resp firestore.CommitResponse
+	err := i.client.RunTransaction(ctx, func(_ context.Context, t *firestore.Transaction) error {
+		s, err := t.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		if err := checkVersion(data, s); err != nil {
+			return err
+		}
+		return t.Set(ref, data)
+	}, firestore.WithCommitResponseTo(&resp))
+	if err != nil {
 		return err
 	}
-	return fmt.Errorf("not implement Pathable")
+	SetVersion(data, resp.CommitTime())
+	return nil
 }
 
-func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, Transaction) error) error {
+func (i *inner) Update(ctx context.Context, data Pathable, updates ...FieldUpdate) error {
+	ctx, span := tracer.Start(ctx, "Update("+reflect.TypeOf(data).String()+")")
+	defer span.End()
+
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return newOpError("Update", path, err)
+	}
+	if err := ValidateUpdates(data, updates); err != nil {
+		return newOpError("Update", path, err)
+	}
+	fu, err := toFirestoreUpdates(updates)
+	if err != nil {
+		return newOpError("Update", path, err)
+	}
+	wr, err := i.client.Doc(path).Update(ctx, fu, preconditions(data)...)
+	if err != nil {
+		return newOpError("Update", path, conflict(err))
+	}
+	SetVersion(data, wr.UpdateTime)
+	return nil
+}
+
+func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, Transaction) error, opts ...TransactionOption) error {
 	ctx, span := tracer.Start(ctx, "Transaction/All")
 	defer span.End()
 
-	err := i.client.RunTransaction(ctx, func(_ctx context.Context, _t *firestore.Transaction) error {
-		_ctx, _span := tracer.Start(_ctx, "Transaction/Process")
-		defer _span.End()
+	o := NewTransactionOptions(opts...)
+	if o.MaxAttempts < 1 {
+		return newOpError("RunTransaction", "", fmt.Errorf("cloudfirestore: invalid max attempts %d", o.MaxAttempts))
+	}
+	if !o.ReadTime.IsZero() {
+		return i.runAt(ctx, o.ReadTime, f)
+	}
+	var txOpts []firestore.TransactionOption
+	if o.ReadOnly {
+		txOpts = append(txOpts, firestore.ReadOnly)
+	}
+	// Only the hooks of the attempt that committed are run.
+	var last *innerTran
+	run := func(attempts int) error {
+		var resp firestore.CommitResponse
+		err := i.client.RunTransaction(ctx, func(_ctx context.Context, _t *firestore.Transaction) error {
+			_ctx, _span := tracer.Start(_ctx, "Transaction/Process")
+			defer _span.End()
 
-		t := &innerTran{
-			client: i.client,
-			tran:   _t,
+			last = &innerTran{
+				client: i.client,
+				tran:   _t,
+			}
+			return f(_ctx, last)
+		}, append(txOpts, firestore.MaxAttempts(attempts), firestore.WithCommitResponseTo(&resp))...)
+		if err != nil {
+			if last != nil && last.preconditioned {
+				err = conflict(err)
+			}
+			return newOpError("RunTransaction", "", err)
 		}
-		return f(_ctx, t)
-	})
-	return err
+		if len(last.versioned) > 0 {
+			commitTime := resp.CommitTime()
+			for _, set := range last.versioned {
+				set(commitTime)
+			}
+		}
+		RunAfterCommit(ctx, last.hooks)
+		return nil
+	}
+	if o.Backoff == nil {
+		return run(o.MaxAttempts)
+	}
+
+	// With a custom backoff every attempt is a separate SDK call, retried
+	// here on contention.
+	for retry := 1; ; retry++ {
+		err := run(1)
+		if !errors.Is(err, ErrContention) || retry >= o.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return newOpError("RunTransaction", "", ctx.Err())
+		case <-time.After(o.Backoff(retry)):
+		}
+	}
 }
 
-func (i *inner) Collection(collectionName string) firestore.Query {
-	return i.client.Collection(collectionName).Query
+func (i *inner) Collection(collectionName string) Query {
+	return CollectionQuery(collectionName)
 }
 
-func (i *inner) CollectionGroup(collectionName string) firestore.Query {
-	return i.client.CollectionGroup(collectionName).Query
+func (i *inner) CollectionGroup(collectionName string) Query {
+	return CollectionGroupQuery(collectionName)
 }
 
-func (i *inner) Sequence(ctx context.Context, q firestore.Query, f func(ctx context.Context, snapshot *firestore.DocumentSnapshot) error) (int, error) {
-	iter := q.Documents(ctx)
+func (i *inner) Sequence(ctx context.Context, q Query, f func(ctx context.Context, doc *Document) error) (int, error) {
+	iter := toFirestoreQuery(i.client, q).Documents(ctx)
 	defer iter.Stop()
+	return sequence(ctx, "Sequence", iter, f)
+}
 
+// sequence calls f for each document of iter, in a span named after op.
+func sequence(ctx context.Context, op string, iter *firestore.DocumentIterator, f func(ctx context.Context, doc *Document) error) (int, error) {
 	num := 0
 	for {
 		s, err := iter.Next()
@@ -147,14 +338,14 @@ func (i *inner) Sequence(ctx context.Context, q firestore.Query, f func(ctx cont
 			break
 		}
 		if err != nil {
-			return num, err
+			return num, newOpError(op, "", err)
 		}
 
-		if err := func(_ctx context.Context, _s *firestore.DocumentSnapshot) error {
-			_ctx, span := tracer.Start(_ctx, "Sequence/Process:"+_s.Ref.ID)
+		if err := func(_ctx context.Context, _d *Document) error {
+			_ctx, span := tracer.Start(_ctx, op+"/Process:"+_d.ID)
 			defer span.End()
-			return f(_ctx, _s)
-		}(ctx, s); err != nil {
+			return f(_ctx, _d)
+		}(ctx, newDocument(s)); err != nil {
 			return num, err
 		}
 		num++
@@ -163,52 +354,80 @@ func (i *inner) Sequence(ctx context.Context, q firestore.Query, f func(ctx cont
 	return num, nil
 }
 
-func (i *inner) Run(ctx context.Context, q firestore.Query, concurrency int, f func(ctx context.Context, snapshot *firestore.DocumentSnapshot) error) (int, error) {
-	iter := q.Documents(ctx)
-	defer iter.Stop()
-
-	var wg sync.WaitGroup
-	var errRet error
-	num := 0
-	ch := make(chan struct{}, concurrency)
-	for {
-		s, err := iter.Next()
-		if err == iterator.Done {
-			break
+func (i *inner) Run(ctx context.Context, q Query, concurrency int, f func(ctx context.Context, doc *Document) error, opts ...RunOption) (int, error) {
+	var iter *firestore.DocumentIterator
+	defer func() {
+		if iter != nil {
+			iter.Stop()
+		}
+	}()
+	return RunDocuments(ctx, concurrency, func(ctx context.Context) (*Document, error) {
+		if iter == nil {
+			iter = toFirestoreQuery(i.client, q).Documents(ctx)
 		}
+		s, err := iter.Next()
 		if err != nil {
-			errRet = err
-			break
+			return nil, err
 		}
+		return newDocument(s), nil
+	}, f, opts...)
+}
 
-		num++
+func (i *inner) DeleteWithQuery(ctx context.Context, q Query, concurrency int, opts ...DeleteOption) (int, error) {
+	ctx, span := tracer.Start(ctx, "DeleteWithQuery")
+	defer span.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := i.newDeleter(ctx, NewDeleteOptions(opts...))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	pages := make(chan []*firestore.DocumentRef)
+	for range max(concurrency, 1) {
 		wg.Add(1)
-		ch <- struct{}{}
-		go func(_ctx context.Context, _s *firestore.DocumentSnapshot, _ch chan struct{}) {
+		go func() {
 			defer wg.Done()
-
-			_ctx, span := tracer.Start(_ctx, "Run/Process:"+_s.Ref.ID)
-			defer span.End()
-			err = f(_ctx, _s)
-			if err != nil {
-				errRet = err
+			for refs := range pages {
+				_ctx, _span := tracer.Start(ctx, "DeleteWithQuery/Page")
+				err := d.deleteDocs(_ctx, refs)
+				_span.End()
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					cancel()
+				}
 			}
-			<-_ch
-		}(ctx, s, ch)
+		}()
 	}
-	wg.Wait()
-	return num, errRet
-}
-
-func (i *inner) DeleteWithQuery(ctx context.Context, q firestore.Query, concurrency int) (int, error) {
-	ctx, span := tracer.Start(ctx, "Transaction/All")
-	defer span.End()
 
-	bw := i.client.BulkWriter(ctx)
-	num, err := i.Sequence(ctx, q, func(_ context.Context, snapshot *firestore.DocumentSnapshot) error {
-		_, err := bw.Delete(snapshot.Ref)
-		return err
+	refs := make([]*firestore.DocumentRef, 0, deletePageSize)
+	_, err := i.Sequence(ctx, q, func(ctx context.Context, doc *Document) error {
+		refs = append(refs, i.client.Doc(doc.Path))
+		if len(refs) < deletePageSize {
+			return nil
+		}
+		select {
+		case pages <- refs:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		refs = make([]*firestore.DocumentRef, 0, deletePageSize)
+		return nil
 	})
-	bw.End()
-	return num, err
+	if err == nil && len(refs) > 0 {
+		pages <- refs
+	}
+	close(pages)
+	wg.Wait()
+
+	// A failed page cancels ctx, which then also ends the query.
+	if err != nil && (len(errs) == 0 || ctx.Err() == nil) {
+		errs = append(errs, err)
+	}
+	return d.end(errors.Join(errs...))
 }
@@ -24,8 +24,6 @@ package cloudfirestore
 
 import (
 	"context"
-
-	"cloud.google.com/go/firestore"
 )
 
 var defaultInstance CloudFirestore
@@ -55,61 +53,153 @@ func Get(ctx context.Context, data Pathable) error {
 	return defaultInstance.Get(ctx, data)
 }
 
+// Update fields of Pathable data
+func Update(ctx context.Context, data Pathable, updates ...FieldUpdate) error {
+	return defaultInstance.Update(ctx, data, updates...)
+}
+
+// Read/Get multiple Pathable data
+func GetAll(ctx context.Context, data []Pathable) ([]error, error) {
+	return defaultInstance.GetAll(ctx, data)
+}
+
+// Check existence of Pathable data
+func Exists(ctx context.Context, data Pathable) (bool, error) {
+	return defaultInstance.Exists(ctx, data)
+}
+
+// Check existence of multiple Pathable data
+func ExistsAll(ctx context.Context, data []Pathable) ([]bool, error) {
+	return defaultInstance.ExistsAll(ctx, data)
+}
+
 // Delete Pathable data
 func Delete(ctx context.Context, data Pathable) error {
 	return defaultInstance.Delete(ctx, data)
 }
 
 // Run transaction
-func RunTransaction(ctx context.Context, f func(context.Context, Transaction) error) error {
-	return defaultInstance.RunTransaction(ctx, f)
+func RunTransaction(ctx context.Context, f func(context.Context, Transaction) error, opts ...TransactionOption) error {
+	return defaultInstance.RunTransaction(ctx, f, opts...)
+}
+
+// Start atomic write batch
+func NewBatch() Batch {
+	return defaultInstance.Batch()
+}
+
+// Start bulk write session
+func Bulk(ctx context.Context) BulkWriter {
+	return defaultInstance.Bulk(ctx)
 }
 
 // Get collection query
-func Collection(collectionName string) firestore.Query {
+func Collection(collectionName string) Query {
 	return defaultInstance.Collection(collectionName)
 }
 
 // Get collection group query
-func CollectionGroup(collectionName string) firestore.Query {
+func CollectionGroup(collectionName string) Query {
 	return defaultInstance.CollectionGroup(collectionName)
 }
 
 // Sequence query
-func Sequence(ctx context.Context, q firestore.Query, f func(context.Context, *firestore.DocumentSnapshot) error) (int, error) {
+func Sequence(ctx context.Context, q Query, f func(context.Context, *Document) error) (int, error) {
 	return defaultInstance.Sequence(ctx, q, f)
 }
 
 // Run query
-func Run(ctx context.Context, q firestore.Query, concurrency int, f func(context.Context, *firestore.DocumentSnapshot) error) (int, error) {
-	return defaultInstance.Run(ctx, q, concurrency, f)
+func Run(ctx context.Context, q Query, concurrency int, f func(context.Context, *Document) error, opts ...RunOption) (int, error) {
+	return defaultInstance.Run(ctx, q, concurrency, f, opts...)
 }
 
 // Delete with Query
-func DeleteWithQuery(ctx context.Context, q firestore.Query, concurrency int) (int, error) {
-	return defaultInstance.DeleteWithQuery(ctx, q, concurrency)
+func DeleteWithQuery(ctx context.Context, q Query, concurrency int, opts ...DeleteOption) (int, error) {
+	return defaultInstance.DeleteWithQuery(ctx, q, concurrency, opts...)
+}
+
+// Delete Pathable data with its subcollections
+func DeleteRecursive(ctx context.Context, data Pathable, opts ...DeleteOption) (int, error) {
+	return defaultInstance.DeleteRecursive(ctx, data, opts...)
+}
+
+// Count query results
+func Count(ctx context.Context, q Query) (int64, error) {
+	return defaultInstance.Count(ctx, q)
+}
+
+// Sum field over query results
+func Sum(ctx context.Context, q Query, field string) (float64, error) {
+	return defaultInstance.Sum(ctx, q, field)
+}
+
+// Average field over query results
+func Average(ctx context.Context, q Query, field string) (float64, error) {
+	return defaultInstance.Average(ctx, q, field)
+}
+
+// Aggregate query results
+func Aggregate(ctx context.Context, q Query, aggregations ...Aggregation) (AggregationResult, error) {
+	return defaultInstance.Aggregate(ctx, q, aggregations...)
+}
+
+// Watch Pathable data
+func Watch(ctx context.Context, data Pathable, f func(context.Context, Change) error) error {
+	return defaultInstance.Watch(ctx, data, f)
+}
+
+// Watch query
+func WatchQuery(ctx context.Context, q Query, f func(context.Context, Change) error) error {
+	return defaultInstance.WatchQuery(ctx, q, f)
 }
 
 // Sequence query
-func TypeSequence[T any](ctx context.Context, q firestore.Query, f func(ctx context.Context, data *T, ref *firestore.DocumentRef) error) (int, error) {
-	return defaultInstance.Sequence(ctx, q, func(ctx context.Context, s *firestore.DocumentSnapshot) error {
+func TypeSequence[T any](ctx context.Context, q Query, f func(ctx context.Context, data *T, doc *Document) error) (int, error) {
+	return defaultInstance.Sequence(ctx, q, func(ctx context.Context, doc *Document) error {
 		data := new(T)
-		if err := s.DataTo(data); err != nil {
+		if err := doc.DataTo(data); err != nil {
 			return err
 		}
-		return f(ctx, data, s.Ref)
+		return f(ctx, data, doc)
 	})
 }
 
 // Run query
-func TypedRun[T any](ctx context.Context, q firestore.Query, concurrency int, f func(ctx context.Context, data *T, ref *firestore.DocumentRef) error) (int, error) {
-	return defaultInstance.Run(ctx, q, concurrency, func(ctx context.Context, s *firestore.DocumentSnapshot) error {
+func TypedRun[T any](ctx context.Context, q Query, concurrency int, f func(ctx context.Context, data *T, doc *Document) error, opts ...RunOption) (int, error) {
+	return defaultInstance.Run(ctx, q, concurrency, func(ctx context.Context, doc *Document) error {
 		data := new(T)
-		if err := s.DataTo(data); err != nil {
+		if err := doc.DataTo(data); err != nil {
 			return err
 		}
-		return f(ctx, data, s.Ref)
-	})
+		return f(ctx, data, doc)
+	}, opts...)
+}
+
+// Read/Get multiple typed Pathable data
+func GetAllAs[T Pathable](ctx context.Context, data []T) ([]error, error) {
+	ps := make([]Pathable, len(data))
+	for idx, d := range data {
+		ps[idx] = d
+	}
+	return defaultInstance.GetAll(ctx, ps)
+}
+
+// Page of query results
+func Page(ctx context.Context, q Query, pageSize int, token string) (*PageResult, error) {
+	return Paginate(ctx, defaultInstance, q, pageSize, token)
+}
+
+// Typed page of query results
+func PageAs[T any](ctx context.Context, q Query, pageSize int, token string) ([]*T, *PageResult, error) {
+	page, err := Paginate(ctx, defaultInstance, q, pageSize, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := pageItems[T](page)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, page, nil
 }
 
 // Return default instance
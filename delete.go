@@ -0,0 +1,224 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// deletePageSize is the number of documents enqueued before waiting for
+// their results.
+const deletePageSize = 500
+
+// DeleteProgress is reported after each deleted or failed document.
+type DeleteProgress struct {
+	Deleted int
+	Failed  int
+	// Path is the document that was just handled.
+	Path string
+}
+
+// DeleteOptions is the configuration built from DeleteOption values.
+type DeleteOptions struct {
+	Recursive bool
+	Progress  func(DeleteProgress)
+	// DryRun, when set, receives the paths that would be deleted and
+	// nothing is deleted.
+	DryRun *[]string
+}
+
+// DeleteOption configures DeleteWithQuery and DeleteRecursive.
+type DeleteOption func(*DeleteOptions)
+
+// NewDeleteOptions applies opts, for backends implementing the deletes.
+func NewDeleteOptions(opts ...DeleteOption) *DeleteOptions {
+	o := &DeleteOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Recursive also deletes the subcollections of each document.
+func Recursive() DeleteOption {
+	return func(o *DeleteOptions) {
+		o.Recursive = true
+	}
+}
+
+// WithDeleteProgress calls f after each document is handled. Calls are
+// serialized.
+func WithDeleteProgress(f func(DeleteProgress)) DeleteOption {
+	return func(o *DeleteOptions) {
+		o.Progress = f
+	}
+}
+
+// DryRun only collects the paths that would be deleted into paths.
+func DryRun(paths *[]string) DeleteOption {
+	return func(o *DeleteOptions) {
+		o.DryRun = paths
+	}
+}
+
+// deleter deletes documents through a BulkWriter, children before parents,
+// and collects the per-document results.
+type deleter struct {
+	// bwMu serializes enqueueing, which the BulkWriter does not guard.
+	bwMu sync.Mutex
+	bw   *firestore.BulkWriter
+	opts *DeleteOptions
+
+	mu      sync.Mutex
+	deleted int
+	errs    []error
+}
+
+func (i *inner) newDeleter(ctx context.Context, opts *DeleteOptions) *deleter {
+	return &deleter{
+		bw:   i.client.BulkWriter(ctx),
+		opts: opts,
+	}
+}
+
+// deleteDocs deletes refs and waits for the results.
+func (d *deleter) deleteDocs(ctx context.Context, refs []*firestore.DocumentRef) error {
+	jobs := make([]*firestore.BulkWriterJob, 0, len(refs))
+	for _, ref := range refs {
+		if d.opts.Recursive {
+			if err := d.deleteSubcollections(ctx, ref); err != nil {
+				return err
+			}
+		}
+		if d.opts.DryRun != nil {
+			d.report(relativePath(ref), nil)
+			continue
+		}
+		job, err := d.enqueue(ref)
+		if err != nil {
+			return newOpError("Delete", relativePath(ref), err)
+		}
+		jobs = append(jobs, job)
+	}
+	if len(jobs) > 0 {
+		d.bw.Flush()
+	}
+	for idx, job := range jobs {
+		_, err := job.Results()
+		d.report(relativePath(refs[idx]), err)
+	}
+	return nil
+}
+
+func (d *deleter) enqueue(ref *firestore.DocumentRef) (*firestore.BulkWriterJob, error) {
+	d.bwMu.Lock()
+	defer d.bwMu.Unlock()
+	return d.bw.Delete(ref)
+}
+
+func (d *deleter) deleteSubcollections(ctx context.Context, ref *firestore.DocumentRef) error {
+	iter := ref.Collections(ctx)
+	for {
+		coll, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return newOpError("ListCollections", relativePath(ref), err)
+		}
+		if err := d.deleteCollection(ctx, coll); err != nil {
+			return err
+		}
+	}
+}
+
+// deleteCollection deletes every document of the collection, including
+// missing documents that only hold subcollections.
+func (d *deleter) deleteCollection(ctx context.Context, coll *firestore.CollectionRef) error {
+	iter := coll.DocumentRefs(ctx)
+	refs := make([]*firestore.DocumentRef, 0, deletePageSize)
+	for {
+		ref, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return newOpError("ListDocuments", coll.Path, err)
+		}
+		refs = append(refs, ref)
+		if len(refs) == deletePageSize {
+			if err := d.deleteDocs(ctx, refs); err != nil {
+				return err
+			}
+			refs = refs[:0]
+		}
+	}
+	return d.deleteDocs(ctx, refs)
+}
+
+func (d *deleter) report(path string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch {
+	case err != nil:
+		d.errs = append(d.errs, newOpError("Delete", path, err))
+	case d.opts.DryRun != nil:
+		*d.opts.DryRun = append(*d.opts.DryRun, path)
+		d.deleted++
+	default:
+		d.deleted++
+	}
+	if d.opts.Progress != nil {
+		d.opts.Progress(DeleteProgress{Deleted: d.deleted, Failed: len(d.errs), Path: path})
+	}
+}
+
+// end closes the BulkWriter and returns the count of deleted documents
+// with err and every per-document failure joined.
+func (d *deleter) end(err error) (int, error) {
+	d.bw.End()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.deleted, errors.Join(append([]error{err}, d.errs...)...)
+}
+
+func (i *inner) DeleteRecursive(ctx context.Context, data Pathable, opts ...DeleteOption) (int, error) {
+	ctx, span := tracer.Start(ctx, "DeleteRecursive("+reflect.TypeOf(data).String()+")")
+	defer span.End()
+
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return 0, newOpError("DeleteRecursive", path, err)
+	}
+	o := NewDeleteOptions(opts...)
+	o.Recursive = true
+	d := i.newDeleter(ctx, o)
+	return d.end(d.deleteDocs(ctx, []*firestore.DocumentRef{i.client.Doc(path)}))
+}
@@ -0,0 +1,102 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// DocumentData decodes the contents of a document read by a backend.
+type DocumentData interface {
+	// DataTo populates the struct or map pointed to by p.
+	DataTo(p any) error
+	// Data returns the fields of the document.
+	Data() map[string]any
+}
+
+// Document is a document returned by a query.
+type Document struct {
+	// ID is the last segment of Path.
+	ID string
+	// Path is the document path such as "users/1/orders/2".
+	Path       string
+	CreateTime time.Time
+	UpdateTime time.Time
+	ReadTime   time.Time
+	DocumentData
+}
+
+// DataTo populates p like DocumentData.DataTo, records the update time as
+// the version of Versioned data and sets the metadata of the document.
+func (d *Document) DataTo(p any) error {
+	if err := d.DocumentData.DataTo(p); err != nil {
+		return err
+	}
+	SetVersion(p, d.UpdateTime)
+	SetMetadata(p, d.Metadata())
+	return nil
+}
+
+// Metadata returns the metadata of the document.
+func (d *Document) Metadata() Metadata {
+	return Metadata{
+		ID:         d.ID,
+		Path:       d.Path,
+		ParentIDs:  parentIDs(d.Path),
+		CreateTime: d.CreateTime,
+		UpdateTime: d.UpdateTime,
+		ReadTime:   d.ReadTime,
+	}
+}
+
+func newDocument(s *firestore.DocumentSnapshot) *Document {
+	return &Document{
+		ID:           s.Ref.ID,
+		Path:         relativePath(s.Ref),
+		CreateTime:   s.CreateTime,
+		UpdateTime:   s.UpdateTime,
+		ReadTime:     s.ReadTime,
+		DocumentData: s,
+	}
+}
+
+// snapshotTo decodes s into data with its metadata and calls AfterGet.
+func snapshotTo(ctx context.Context, s *firestore.DocumentSnapshot, data any) error {
+	if err := newDocument(s).DataTo(data); err != nil {
+		return err
+	}
+	return AfterGet(ctx, data)
+}
+
+// relativePath strips the "projects/.../documents/" prefix of a reference.
+func relativePath(ref *firestore.DocumentRef) string {
+	const sep = "/documents/"
+	if idx := strings.Index(ref.Path, sep); idx >= 0 {
+		return ref.Path[idx+len(sep):]
+	}
+	return ref.Path
+}
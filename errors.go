@@ -0,0 +1,131 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound is reported when the document doesn't exist.
+	ErrNotFound = errors.New("cloudfirestore: document not found")
+	// ErrAlreadyExists is reported when Create finds an existing document.
+	ErrAlreadyExists = errors.New("cloudfirestore: document already exists")
+	// ErrNotPathable is reported when the data doesn't implement Pathable.
+	ErrNotPathable = errors.New("cloudfirestore: not implement Pathable")
+	// ErrContention is reported when a transaction is aborted by contention.
+	ErrContention = errors.New("cloudfirestore: transaction contention")
+	// ErrConflict is reported when versioned data is outdated.
+	ErrConflict = errors.New("cloudfirestore: version conflict")
+	// ErrInvalidPath is reported when Path returns a malformed document
+	// path.
+	ErrInvalidPath = errors.New("cloudfirestore: invalid document path")
+)
+
+// OpError records the failed operation and the document path it targeted.
+type OpError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+func (e *OpError) Error() string {
+	if e.Path == "" {
+		return e.Op + ": " + e.Err.Error()
+	}
+	return e.Op + " " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *OpError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err means the document doesn't exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRetryable reports whether the operation may succeed if tried again.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrContention) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Aborted, codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// kindError keeps the original error message while also matching a sentinel.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+func classify(err error) error {
+	var kind error
+	switch status.Code(err) {
+	case codes.NotFound:
+		kind = ErrNotFound
+	case codes.AlreadyExists:
+		kind = ErrAlreadyExists
+	case codes.Aborted:
+		kind = ErrContention
+	default:
+		return err
+	}
+	if errors.Is(err, kind) {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+func newOpError(op, path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var oe *OpError
+	if errors.As(err, &oe) {
+		return err
+	}
+	return &OpError{Op: op, Path: path, Err: classify(err)}
+}
+
+// NewOpError wraps err in an OpError like the Firestore backend does, so that
+// gRPC status codes match the sentinel errors. It is for backends and test
+// doubles; an err that already is an OpError is returned as is.
+func NewOpError(op, path string, err error) error {
+	return newOpError(op, path, err)
+}
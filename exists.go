@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"reflect"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// existsBatchSize is the most values an "in" filter accepts.
+const existsBatchSize = 30
+
+func (i *inner) Exists(ctx context.Context, data Pathable) (bool, error) {
+	ctx, span := tracer.Start(ctx, "Exists("+reflect.TypeOf(data).String()+")")
+	defer span.End()
+
+	found, err := existsAll(ctx, "Exists", i.client, []Pathable{data}, func(q firestore.Query) *firestore.DocumentIterator {
+		return q.Documents(ctx)
+	})
+	if err != nil {
+		return false, err
+	}
+	return found[0], nil
+}
+
+func (i *inner) ExistsAll(ctx context.Context, data []Pathable) ([]bool, error) {
+	ctx, span := tracer.Start(ctx, "ExistsAll")
+	defer span.End()
+
+	return existsAll(ctx, "ExistsAll", i.client, data, func(q firestore.Query) *firestore.DocumentIterator {
+		return q.Documents(ctx)
+	})
+}
+
+func (i *innerTran) Exists(ctx context.Context, data Pathable) (bool, error) {
+	found, err := existsAll(ctx, "Exists", i.client, []Pathable{data}, func(q firestore.Query) *firestore.DocumentIterator {
+		return i.tran.Documents(q)
+	})
+	if err != nil {
+		return false, err
+	}
+	return found[0], nil
+}
+
+func (i *innerTran) ExistsAll(ctx context.Context, data []Pathable) ([]bool, error) {
+	return existsAll(ctx, "ExistsAll", i.client, data, func(q firestore.Query) *firestore.DocumentIterator {
+		return i.tran.Documents(q)
+	})
+}
+
+// existsAll looks the documents up with queries selecting no fields, so only
+// their names are transferred, batching the documents of each collection.
+func existsAll(ctx context.Context, op string, client *firestore.Client, data []Pathable, documents func(firestore.Query) *firestore.DocumentIterator) ([]bool, error) {
+	paths := make([]string, len(data))
+	batches := map[string][]*firestore.DocumentRef{}
+	var order []*firestore.CollectionRef
+	for idx, d := range data {
+		paths[idx] = d.Path(ctx)
+		if err := ValidatePath(d, paths[idx]); err != nil {
+			return nil, newOpError(op, paths[idx], err)
+		}
+		ref := client.Doc(paths[idx])
+		if _, ok := batches[ref.Parent.Path]; !ok {
+			order = append(order, ref.Parent)
+		}
+		batches[ref.Parent.Path] = append(batches[ref.Parent.Path], ref)
+	}
+
+	found := map[string]bool{}
+	for _, coll := range order {
+		refs := batches[coll.Path]
+		for start := 0; start < len(refs); start += existsBatchSize {
+			chunk := refs[start:min(start+existsBatchSize, len(refs))]
+			iter := documents(coll.Where(firestore.DocumentID, "in", chunk).Select())
+			for {
+				s, err := iter.Next()
+				if err == iterator.Done {
+					break
+				}
+				if err != nil {
+					iter.Stop()
+					return nil, newOpError(op, "", err)
+				}
+				found[relativePath(s.Ref)] = true
+			}
+			iter.Stop()
+		}
+	}
+
+	ret := make([]bool, len(data))
+	for idx, path := range paths {
+		ret[idx] = found[path]
+	}
+	return ret, nil
+}
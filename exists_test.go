@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type existsItem struct {
+	ID string `firestore:"-"`
+}
+
+func (e *existsItem) Path(context.Context) string {
+	return "items/" + e.ID
+}
+
+// testExists checks a backend holding items/0 to items/39.
+func testExists(t *testing.T, c cloudfirestore.CloudFirestore) {
+	ctx := context.Background()
+	for id, want := range map[string]bool{"0": true, "39": true, "40": false} {
+		got, err := c.Exists(ctx, &existsItem{ID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Exists(items/%s) = %t, want %t", id, got, want)
+		}
+	}
+
+	// More documents than one "in" filter takes, spread over two
+	// collections, out of order and with a duplicate.
+	var data []cloudfirestore.Pathable
+	var want []bool
+	for idx := 44; idx >= 0; idx-- {
+		data = append(data, &existsItem{ID: fmt.Sprint(idx)})
+		want = append(want, idx < 40)
+		if idx == 20 {
+			data = append(data, rawPath("other/1"), &existsItem{ID: "3"})
+			want = append(want, false, true)
+		}
+	}
+	got, err := c.ExistsAll(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ExistsAll returned %d results, want %d", len(got), len(want))
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Errorf("ExistsAll(%s) = %t, want %t", data[idx].Path(ctx), got[idx], want[idx])
+		}
+	}
+}
+
+func TestExistsMemory(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	for idx := range 40 {
+		if err := c.Set(ctx, &existsItem{ID: fmt.Sprint(idx)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testExists(t, c)
+}
+
+func TestExistsFirestore(t *testing.T) {
+	testExists(t, newFakeClient(t, newFakeFirestore(40)))
+}
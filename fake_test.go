@@ -0,0 +1,163 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	pb "cloud.google.com/go/firestore/apiv1/firestorepb"
+	"github.com/Eigen438/cloudfirestore"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeFirestore serves a fixed collection and accepts batch writes unless
+// fail returns a code for them.
+type fakeFirestore struct {
+	pb.UnimplementedFirestoreServer
+	database string
+	docs     int
+	// fail returns the code for the given attempt at writing the document.
+	fail func(name string, attempt int) codes.Code
+
+	mu     sync.Mutex
+	writes map[string]int
+	// readTimes are the read times of the reads, zero for current data.
+	readTimes []time.Time
+}
+
+func newFakeFirestore(docs int) *fakeFirestore {
+	return &fakeFirestore{
+		database: "projects/test/databases/(default)",
+		docs:     docs,
+		writes:   map[string]int{},
+	}
+}
+
+func (f *fakeFirestore) readAt(rt *timestamppb.Timestamp) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var t time.Time
+	if rt != nil {
+		t = rt.AsTime()
+	}
+	f.readTimes = append(f.readTimes, t)
+}
+
+// BatchGetDocuments reports every document as missing.
+func (f *fakeFirestore) BatchGetDocuments(req *pb.BatchGetDocumentsRequest, stream pb.Firestore_BatchGetDocumentsServer) error {
+	f.readAt(req.GetReadTime())
+	for _, name := range req.Documents {
+		if err := stream.Send(&pb.BatchGetDocumentsResponse{
+			Result:   &pb.BatchGetDocumentsResponse_Missing{Missing: name},
+			ReadTime: timestamppb.Now(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunQuery returns the documents of items, restricted to those named by a
+// "__name__ in" filter if the query has one.
+func (f *fakeFirestore) RunQuery(req *pb.RunQueryRequest, stream pb.Firestore_RunQueryServer) error {
+	f.readAt(req.GetReadTime())
+	var names map[string]bool
+	if ff := req.GetStructuredQuery().GetWhere().GetFieldFilter(); ff.GetField().GetFieldPath() == "__name__" && ff.GetOp() == pb.StructuredQuery_FieldFilter_IN {
+		names = map[string]bool{}
+		for _, v := range ff.GetValue().GetArrayValue().GetValues() {
+			names[v.GetReferenceValue()] = true
+		}
+	}
+	now := timestamppb.Now()
+	for idx := range f.docs {
+		name := fmt.Sprintf("%s/documents/items/%d", f.database, idx)
+		if names != nil && !names[name] {
+			continue
+		}
+		if err := stream.Send(&pb.RunQueryResponse{
+			Document: &pb.Document{
+				Name:       name,
+				CreateTime: now,
+				UpdateTime: now,
+			},
+			ReadTime: now,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeFirestore) BatchWrite(_ context.Context, req *pb.BatchWriteRequest) (*pb.BatchWriteResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &pb.BatchWriteResponse{}
+	for _, w := range req.Writes {
+		name := w.GetDelete()
+		if name == "" {
+			name = w.GetUpdate().GetName()
+		}
+		f.writes[name]++
+		code := codes.OK
+		if f.fail != nil {
+			code = f.fail(name, f.writes[name])
+		}
+		result := &pb.WriteResult{}
+		if code == codes.OK {
+			result.UpdateTime = timestamppb.Now()
+		}
+		resp.WriteResults = append(resp.WriteResults, result)
+		resp.Status = append(resp.Status, &status.Status{Code: int32(code), Message: code.String()})
+	}
+	return resp, nil
+}
+
+// newFakeClient returns a client talking to f through the emulator support
+// of the SDK.
+func newFakeClient(t *testing.T, f *fakeFirestore) cloudfirestore.CloudFirestore {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterFirestoreServer(srv, f)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	t.Setenv("FIRESTORE_EMULATOR_HOST", lis.Addr().String())
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "test")
+	c, err := cloudfirestore.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,81 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import "context"
+
+// BeforeCreator is called before the data is created. Returning an error
+// aborts the Create.
+type BeforeCreator interface {
+	BeforeCreate(context.Context) error
+}
+
+// BeforeSetter is called before the data is written by Set. Returning an
+// error aborts the Set.
+type BeforeSetter interface {
+	BeforeSet(context.Context) error
+}
+
+// AfterGetter is called after the data is read by Get or GetAll. Returning
+// an error fails the read.
+type AfterGetter interface {
+	AfterGet(context.Context) error
+}
+
+// BeforeDeleter is called before the data is deleted. Returning an error
+// aborts the Delete.
+type BeforeDeleter interface {
+	BeforeDelete(context.Context) error
+}
+
+// BeforeCreate calls the BeforeCreator hook of data, if any, for backends.
+func BeforeCreate(ctx context.Context, data any) error {
+	if h, ok := data.(BeforeCreator); ok {
+		return h.BeforeCreate(ctx)
+	}
+	return nil
+}
+
+// BeforeSet calls the BeforeSetter hook of data, if any, for backends.
+func BeforeSet(ctx context.Context, data any) error {
+	if h, ok := data.(BeforeSetter); ok {
+		return h.BeforeSet(ctx)
+	}
+	return nil
+}
+
+// AfterGet calls the AfterGetter hook of data, if any, for backends.
+func AfterGet(ctx context.Context, data any) error {
+	if h, ok := data.(AfterGetter); ok {
+		return h.AfterGet(ctx)
+	}
+	return nil
+}
+
+// BeforeDelete calls the BeforeDeleter hook of data, if any, for backends.
+func BeforeDelete(ctx context.Context, data any) error {
+	if h, ok := data.(BeforeDeleter); ok {
+		return h.BeforeDelete(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AutoID is implemented by data whose ID is allocated by Create. When the ID
+// is empty, Create generates one, stores it with SetID and writes the
+// document. The ID is read from the last placeholder of the path template,
+// or else the field tagged `cloudfirestore:"id"`; without either, it is
+// empty when Path equals CollectionPath, optionally followed by "/".
+type AutoID interface {
+	CollectionPath(context.Context) string
+	SetID(string)
+}
+
+// IDGenerating is implemented by AutoID data using its own generator
+// instead of the package default.
+type IDGenerating interface {
+	GenerateID() string
+}
+
+// IDGenerator returns a new document ID.
+type IDGenerator func() string
+
+var (
+	idGeneratorMu sync.RWMutex
+	idGenerator   IDGenerator = RandomID
+)
+
+// SetIDGenerator sets the default generator of AutoID data, RandomID unless
+// changed.
+func SetIDGenerator(g IDGenerator) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	idGenerator = g
+}
+
+// AllocateID sets a new ID on AutoID data that has none, for backends
+// implementing Create.
+func AllocateID(ctx context.Context, data any) {
+	a, ok := data.(AutoID)
+	if !ok {
+		return
+	}
+	if id, ok := idOf(data); ok {
+		if id != "" {
+			return
+		}
+	} else if p, ok := data.(Pathable); !ok || strings.TrimSuffix(p.Path(ctx), "/") != strings.TrimSuffix(a.CollectionPath(ctx), "/") {
+		return
+	}
+	if g, ok := data.(IDGenerating); ok {
+		a.SetID(g.GenerateID())
+		return
+	}
+	idGeneratorMu.RLock()
+	g := idGenerator
+	idGeneratorMu.RUnlock()
+	a.SetID(g())
+}
+
+// idOf returns the ID field of data, the last placeholder of its path
+// template or the field tagged "id". It reports false when there is none.
+func idOf(data any) (string, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(data))
+	if rv.Kind() != reflect.Struct {
+		return "", false
+	}
+	var f reflect.StructField
+	var ok bool
+	if t, _ := templateOf(data); t != nil && t.fields[len(t.fields)-1] {
+		f, ok = stringField(rv.Type(), t.segments[len(t.segments)-1])
+	} else {
+		f, ok = taggedFields(rv.Type())["id"]
+		ok = ok && f.Type.Kind() == reflect.String
+	}
+	if !ok {
+		return "", false
+	}
+	fv, err := rv.FieldByIndexErr(f.Index)
+	if err != nil {
+		// A nil embedded struct holds no ID yet.
+		return "", true
+	}
+	return fv.String(), true
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic("cloudfirestore: crypto/rand: " + err.Error())
+	}
+}
+
+const autoIDChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// RandomID returns 20 random alphanumeric characters, like the IDs
+// Firestore allocates.
+func RandomID() string {
+	b := make([]byte, 20)
+	// 248 is the largest multiple of 62 below 256, so the choice is unbiased.
+	var buf [32]byte
+	for n := 0; n < len(b); {
+		randomBytes(buf[:])
+		for _, c := range buf {
+			if c < 248 && n < len(b) {
+				b[n] = autoIDChars[int(c)%len(autoIDChars)]
+				n++
+			}
+		}
+	}
+	return string(b)
+}
+
+// UUIDv7 returns a time-ordered UUID version 7 in its canonical form.
+func UUIDv7() string {
+	var u [16]byte
+	randomBytes(u[6:])
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixMilli()))
+	copy(u[:6], ms[2:])
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+
+	var s [36]byte
+	hex.Encode(s[0:8], u[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], u[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], u[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], u[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], u[10:])
+	return string(s[:])
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID returns a lexicographically sortable 26 character ULID.
+func ULID() string {
+	var u [16]byte
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixMilli()))
+	copy(u[:6], ms[2:])
+	randomBytes(u[6:])
+
+	// 128 bits as 26 base32 digits, the first one holding 3 bits.
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	var s [26]byte
+	for i := 25; i >= 0; i-- {
+		s[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
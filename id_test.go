@@ -0,0 +1,90 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type autoNote struct {
+	ID    string `firestore:"-"`
+	Title string
+}
+
+func (n *autoNote) Path(context.Context) string           { return "notes/" + n.ID }
+func (n *autoNote) CollectionPath(context.Context) string { return "notes" }
+func (n *autoNote) SetID(id string)                       { n.ID = id }
+
+type autoOrder struct {
+	_      struct{} `cloudfirestore:"path=users/{UserID}/orders/{ID}"`
+	UserID string   `firestore:"-"`
+	ID     string   `firestore:"-"`
+	Total  int
+}
+
+func (o *autoOrder) Path(context.Context) string { return cloudfirestore.TemplatePath(o) }
+func (o *autoOrder) CollectionPath(context.Context) string {
+	return "users/" + o.UserID + "/orders"
+}
+func (o *autoOrder) SetID(id string) { o.ID = id }
+
+func TestAllocateID(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+
+	note := &autoNote{Title: "a"}
+	if err := c.Create(ctx, note); err != nil {
+		t.Fatal(err)
+	}
+	if note.ID == "" {
+		t.Fatal("Create did not allocate an ID for a hand-written Path")
+	}
+	if err := c.Get(ctx, &autoNote{ID: note.ID}); err != nil {
+		t.Errorf("Get = %v", err)
+	}
+
+	order := &autoOrder{UserID: "u", Total: 1}
+	if err := c.Create(ctx, order); err != nil {
+		t.Fatal(err)
+	}
+	if order.ID == "" {
+		t.Fatal("Create did not allocate an ID for a path template")
+	}
+	got := &autoOrder{UserID: "u", ID: order.ID}
+	if err := c.Get(ctx, got); err != nil || got.Total != 1 {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+
+	// An ID that is already set is kept.
+	kept := &autoOrder{UserID: "u", ID: "mine"}
+	if err := c.Create(ctx, kept); err != nil {
+		t.Fatal(err)
+	}
+	if kept.ID != "mine" {
+		t.Errorf("ID = %q, want mine", kept.ID)
+	}
+}
@@ -24,8 +24,6 @@ package cloudfirestore
 
 import (
 	"context"
-
-	"cloud.google.com/go/firestore"
 )
 
 type Pathable interface {
@@ -41,24 +39,62 @@ type CloudFirestore interface {
 	Delete(context.Context, any) error
 	// Get retrieves the document.
 	Get(context.Context, any) error
+	// GetAll retrieves the documents in a single batch. The returned slice has
+	// one entry per document, holding ErrNotFound or a decode error for the
+	// documents that couldn't be loaded.
+	GetAll(context.Context, []Pathable) ([]error, error)
+	// Exists reports whether the document exists, without reading its data.
+	Exists(context.Context, Pathable) (bool, error)
+	// ExistsAll reports whether each document exists, without reading their
+	// data.
+	ExistsAll(context.Context, []Pathable) ([]bool, error)
 	// Set creates or overwrites the document with the given data.
 	Set(context.Context, any) error
+	// Update changes only the given fields of the document.
+	// It returns an error if the document doesn't exist.
+	Update(context.Context, Pathable, ...FieldUpdate) error
 
 	// Run transaction
-	RunTransaction(context.Context, func(context.Context, Transaction) error) error
+	RunTransaction(context.Context, func(context.Context, Transaction) error, ...TransactionOption) error
+
+	// Batch starts a set of writes committed atomically.
+	Batch() Batch
+
+	// Bulk starts a session for large numbers of independent writes.
+	Bulk(context.Context) BulkWriter
 
 	// Get collection query
-	Collection(string) firestore.Query
+	Collection(string) Query
 	// Get collection group query
-	CollectionGroup(string) firestore.Query
+	CollectionGroup(string) Query
 
 	// Sequence query
-	Sequence(context.Context, firestore.Query, func(context.Context, *firestore.DocumentSnapshot) error) (int, error)
-	// Run query
-	Run(context.Context, firestore.Query, int, func(context.Context, *firestore.DocumentSnapshot) error) (int, error)
+	Sequence(context.Context, Query, func(context.Context, *Document) error) (int, error)
+	// Run query with up to the given number of concurrent handlers. By
+	// default the first failure cancels the remaining work.
+	Run(context.Context, Query, int, func(context.Context, *Document) error, ...RunOption) (int, error)
 
 	// Delete with Query
-	DeleteWithQuery(context.Context, firestore.Query, int) (int, error)
+	DeleteWithQuery(context.Context, Query, int, ...DeleteOption) (int, error)
+	// DeleteRecursive deletes the document and all of its subcollections.
+	DeleteRecursive(context.Context, Pathable, ...DeleteOption) (int, error)
+
+	// Count returns the number of documents matching the query.
+	Count(context.Context, Query) (int64, error)
+	// Sum returns the sum of the numeric field over the query results.
+	Sum(context.Context, Query, string) (float64, error)
+	// Average returns the average of the numeric field over the query
+	// results, or 0 when there is nothing to average.
+	Average(context.Context, Query, string) (float64, error)
+	// Aggregate computes several aggregations in one request.
+	Aggregate(context.Context, Query, ...Aggregation) (AggregationResult, error)
+
+	// Watch calls the handler for each change of the document until the
+	// context is done or the handler returns an error.
+	Watch(context.Context, Pathable, func(context.Context, Change) error) error
+	// WatchQuery calls the handler for each change of the query results until
+	// the context is done or the handler returns an error.
+	WatchQuery(context.Context, Query, func(context.Context, Change) error) error
 }
 
 type Transaction interface {
@@ -68,6 +104,19 @@ type Transaction interface {
 	Set(context.Context, any) error
 	// Read/Get Pathable data in transaction
 	Get(context.Context, any) error
+	// Read/Get multiple Pathable data in transaction
+	GetAll(context.Context, []Pathable) ([]error, error)
+	// Check existence of Pathable data in transaction
+	Exists(context.Context, Pathable) (bool, error)
+	// Check existence of multiple Pathable data in transaction
+	ExistsAll(context.Context, []Pathable) ([]bool, error)
 	// Delete Pathable data in transaction
 	Delete(context.Context, any) error
+	// Update fields of Pathable data in transaction
+	Update(context.Context, Pathable, ...FieldUpdate) error
+	// Sequence query in transaction; all reads must come before writes
+	Query(context.Context, Query, func(context.Context, *Document) error) (int, error)
+	// AfterCommit registers f to run once the transaction has committed.
+	// Registrations of an attempt that is retried are discarded.
+	AfterCommit(f func(context.Context))
 }
@@ -0,0 +1,96 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+func (i *inner) Count(ctx context.Context, q cloudfirestore.Query) (int64, error) {
+	res, err := i.Aggregate(ctx, q, cloudfirestore.CountOf("count"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Int64("count"), nil
+}
+
+func (i *inner) Sum(ctx context.Context, q cloudfirestore.Query, field string) (float64, error) {
+	res, err := i.Aggregate(ctx, q, cloudfirestore.SumOf(field, "sum"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Float64("sum"), nil
+}
+
+func (i *inner) Average(ctx context.Context, q cloudfirestore.Query, field string) (float64, error) {
+	res, err := i.Aggregate(ctx, q, cloudfirestore.AverageOf(field, "average"))
+	if err != nil {
+		return 0, err
+	}
+	return res.Float64("average"), nil
+}
+
+func (i *inner) Aggregate(_ context.Context, q cloudfirestore.Query, aggregations ...cloudfirestore.Aggregation) (cloudfirestore.AggregationResult, error) {
+	results, _, err := i.store.evaluate(q)
+	if err != nil {
+		return nil, &cloudfirestore.OpError{Op: "Aggregate", Err: err}
+	}
+	ret := cloudfirestore.AggregationResult{}
+	for _, a := range aggregations {
+		switch a.Kind {
+		case cloudfirestore.AggregateCount:
+			ret[a.Alias] = int64(len(results))
+		case cloudfirestore.AggregateSum, cloudfirestore.AggregateAverage:
+			var isum int64
+			var fsum float64
+			isFloat := false
+			n := 0
+			for _, r := range results {
+				v, _ := fieldValue(r.path, r.doc.data, a.Field)
+				switch t := v.(type) {
+				case int64:
+					isum += t
+				case float64:
+					fsum += t
+					isFloat = true
+				default:
+					continue
+				}
+				n++
+			}
+			switch {
+			case a.Kind == cloudfirestore.AggregateAverage && n == 0:
+				ret[a.Alias] = nil
+			case a.Kind == cloudfirestore.AggregateAverage:
+				ret[a.Alias] = (float64(isum) + fsum) / float64(n)
+			case isFloat:
+				ret[a.Alias] = float64(isum) + fsum
+			default:
+				ret[a.Alias] = isum
+			}
+		}
+	}
+	return ret, nil
+}
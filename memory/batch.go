@@ -0,0 +1,143 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+// innerBatch commits its writes with a single store commit.
+type innerBatch struct {
+	store *store
+
+	mu        sync.Mutex
+	writes    []write
+	committed bool
+}
+
+func (i *inner) Batch() cloudfirestore.Batch {
+	return &innerBatch{
+		store: i.store,
+	}
+}
+
+func (b *innerBatch) Create(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Create", Err: cloudfirestore.ErrNotPathable}
+	}
+	cloudfirestore.AllocateID(ctx, data)
+	if err := cloudfirestore.BeforeCreate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	return b.add(createWrite(path, m))
+}
+
+func (b *innerBatch) Set(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Set", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeSet(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	return b.add(setWrite(path, m))
+}
+
+func (b *innerBatch) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	if err := cloudfirestore.ValidateUpdates(data, updates); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	w, err := updateWrite(path, updates)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	return b.add(w)
+}
+
+func (b *innerBatch) Delete(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Delete", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeDelete(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: path, Err: err}
+	}
+	return b.add(deleteWrite(path))
+}
+
+func (b *innerBatch) add(w write) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.committed {
+		return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: cloudfirestore.ErrBatchCommitted}
+	}
+	if len(b.writes) >= cloudfirestore.MaxBatchWrites {
+		return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: cloudfirestore.ErrBatchTooLarge}
+	}
+	b.writes = append(b.writes, w)
+	return nil
+}
+
+func (b *innerBatch) Commit(context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.committed {
+		return &cloudfirestore.OpError{Op: "Commit", Err: cloudfirestore.ErrBatchCommitted}
+	}
+	b.committed = true
+	return b.store.commit(nil, b.writes)
+}
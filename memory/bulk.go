@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+var errBulkClosed = errors.New("memory: bulk writer is closed")
+
+// innerBulk applies the queued writes one by one when flushed.
+type innerBulk struct {
+	store *store
+
+	mu      sync.Mutex
+	pending []write
+	closed  bool
+}
+
+func (i *inner) Bulk(context.Context) cloudfirestore.BulkWriter {
+	return &innerBulk{
+		store: i.store,
+	}
+}
+
+func (b *innerBulk) Create(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Create", Err: cloudfirestore.ErrNotPathable}
+	}
+	cloudfirestore.AllocateID(ctx, data)
+	if err := cloudfirestore.BeforeCreate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	return b.enqueue(createWrite(path, m))
+}
+
+func (b *innerBulk) Set(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Set", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeSet(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	return b.enqueue(setWrite(path, m))
+}
+
+func (b *innerBulk) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	if err := cloudfirestore.ValidateUpdates(data, updates); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	w, err := updateWrite(path, updates)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	return b.enqueue(w)
+}
+
+func (b *innerBulk) Delete(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Delete", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeDelete(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: path, Err: err}
+	}
+	return b.enqueue(deleteWrite(path))
+}
+
+func (b *innerBulk) enqueue(w write) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: errBulkClosed}
+	}
+	b.pending = append(b.pending, w)
+	return nil
+}
+
+func (b *innerBulk) Flush(context.Context) (cloudfirestore.BulkSummary, error) {
+	return b.flush(false)
+}
+
+func (b *innerBulk) End(context.Context) (cloudfirestore.BulkSummary, error) {
+	return b.flush(true)
+}
+
+func (b *innerBulk) flush(end bool) (cloudfirestore.BulkSummary, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var summary cloudfirestore.BulkSummary
+	var errs []error
+	for _, w := range b.pending {
+		if err := b.store.commit(nil, []write{w}); err != nil {
+			summary.Failed++
+			if summary.Errors == nil {
+				summary.Errors = map[string]error{}
+			}
+			summary.Errors[w.path] = err
+			errs = append(errs, err)
+			continue
+		}
+		summary.Succeeded++
+	}
+	b.pending = nil
+	b.closed = b.closed || end
+	return summary, errors.Join(errs...)
+}
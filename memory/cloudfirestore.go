@@ -0,0 +1,249 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package memory implements cloudfirestore.CloudFirestore in process, so
+// code built on it can be tested without a project or an emulator.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+// errReadTime is returned for ReadTime, as the store keeps no history.
+var errReadTime = fmt.Errorf("memory: read time: %w", errors.ErrUnsupported)
+
+type inner struct {
+	store *store
+}
+
+func New() cloudfirestore.CloudFirestore {
+	return &inner{
+		store: newStore(),
+	}
+}
+
+func (i *inner) Create(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Create", Err: cloudfirestore.ErrNotPathable}
+	}
+	cloudfirestore.AllocateID(ctx, data)
+	if err := cloudfirestore.BeforeCreate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	return i.store.commit(nil, []write{versioned(createWrite(path, m), data)})
+}
+
+func (i *inner) Delete(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Delete", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeDelete(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: path, Err: err}
+	}
+	return i.store.commit(nil, []write{versioned(deleteWrite(path), data)})
+}
+
+func (i *inner) Get(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Get", Err: cloudfirestore.ErrNotPathable}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Get", Path: path, Err: err}
+	}
+	return dataTo(ctx, "Get", path, i.store.get(path), data)
+}
+
+func (i *inner) GetAll(ctx context.Context, data []cloudfirestore.Pathable) ([]error, error) {
+	errs := make([]error, len(data))
+	for idx, d := range data {
+		path := d.Path(ctx)
+		if err := cloudfirestore.ValidatePath(d, path); err != nil {
+			return nil, &cloudfirestore.OpError{Op: "GetAll", Path: path, Err: err}
+		}
+		errs[idx] = dataTo(ctx, "GetAll", path, i.store.get(path), d)
+	}
+	return errs, nil
+}
+
+func (i *inner) Set(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Set", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeSet(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	return i.store.commit(nil, []write{versioned(setWrite(path, m), data)})
+}
+
+func (i *inner) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	if err := cloudfirestore.ValidateUpdates(data, updates); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	w, err := updateWrite(path, updates)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	return i.store.commit(nil, []write{versioned(w, data)})
+}
+
+func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, cloudfirestore.Transaction) error, opts ...cloudfirestore.TransactionOption) error {
+	o := cloudfirestore.NewTransactionOptions(opts...)
+	if o.MaxAttempts < 1 {
+		return &cloudfirestore.OpError{Op: "RunTransaction", Err: fmt.Errorf("memory: invalid max attempts %d", o.MaxAttempts)}
+	}
+	if !o.ReadTime.IsZero() {
+		return &cloudfirestore.OpError{Op: "RunTransaction", Err: errReadTime}
+	}
+	for n := 1; n <= o.MaxAttempts; n++ {
+		if n > 1 && o.Backoff != nil {
+			select {
+			case <-ctx.Done():
+				return &cloudfirestore.OpError{Op: "RunTransaction", Err: ctx.Err()}
+			case <-time.After(o.Backoff(n - 1)):
+			}
+		}
+		t := &innerTran{
+			store:    i.store,
+			reads:    map[string]int64{},
+			readOnly: o.ReadOnly,
+		}
+		err := f(ctx, t)
+		if err == nil {
+			err = i.store.commit(t.reads, t.writes, t.queries...)
+		}
+		if err == nil {
+			cloudfirestore.RunAfterCommit(ctx, t.hooks)
+			return nil
+		}
+		if !errors.Is(err, cloudfirestore.ErrContention) {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return &cloudfirestore.OpError{Op: "RunTransaction", Err: err}
+		}
+	}
+	return &cloudfirestore.OpError{Op: "RunTransaction", Err: cloudfirestore.ErrContention}
+}
+
+func (i *inner) Collection(collectionName string) cloudfirestore.Query {
+	return cloudfirestore.CollectionQuery(collectionName)
+}
+
+func (i *inner) CollectionGroup(collectionName string) cloudfirestore.Query {
+	return cloudfirestore.CollectionGroupQuery(collectionName)
+}
+
+func (i *inner) Sequence(ctx context.Context, q cloudfirestore.Query, f func(context.Context, *cloudfirestore.Document) error) (int, error) {
+	docs, err := i.store.query(q)
+	if err != nil {
+		return 0, &cloudfirestore.OpError{Op: "Sequence", Err: err}
+	}
+	for num, doc := range docs {
+		if err := f(ctx, doc); err != nil {
+			return num, err
+		}
+	}
+	return len(docs), nil
+}
+
+func (i *inner) Run(ctx context.Context, q cloudfirestore.Query, concurrency int, f func(context.Context, *cloudfirestore.Document) error, opts ...cloudfirestore.RunOption) (int, error) {
+	docs, err := i.store.query(q)
+	if err != nil {
+		return 0, &cloudfirestore.OpError{Op: "Run", Err: err}
+	}
+	return cloudfirestore.RunDocuments(ctx, concurrency, cloudfirestore.SliceDocuments(docs), f, opts...)
+}
+
+func (i *inner) DeleteWithQuery(ctx context.Context, q cloudfirestore.Query, concurrency int, opts ...cloudfirestore.DeleteOption) (int, error) {
+	docs, err := i.store.query(q)
+	if err != nil {
+		return 0, &cloudfirestore.OpError{Op: "DeleteWithQuery", Err: err}
+	}
+	paths := make([]string, len(docs))
+	for idx, doc := range docs {
+		paths[idx] = doc.Path
+	}
+	return i.store.deletePaths(paths, cloudfirestore.NewDeleteOptions(opts...))
+}
+
+func (i *inner) DeleteRecursive(ctx context.Context, data cloudfirestore.Pathable, opts ...cloudfirestore.DeleteOption) (int, error) {
+	o := cloudfirestore.NewDeleteOptions(opts...)
+	o.Recursive = true
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return 0, &cloudfirestore.OpError{Op: "DeleteRecursive", Path: path, Err: err}
+	}
+	return i.store.deletePaths([]string{path}, o)
+}
+
+func dataTo(ctx context.Context, op, path string, d *document, data any) error {
+	if d == nil {
+		return &cloudfirestore.OpError{Op: op, Path: path, Err: cloudfirestore.ErrNotFound}
+	}
+	if err := newDocument(path, d, time.Now()).DataTo(data); err != nil {
+		return &cloudfirestore.OpError{Op: op, Path: path, Err: err}
+	}
+	if err := cloudfirestore.AfterGet(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: op, Path: path, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type book struct {
+	ID    string `firestore:"-"`
+	Title string
+	Year  int
+}
+
+func (b *book) Path(context.Context) string {
+	return "books/" + b.ID
+}
+
+func TestCreateAlreadyExists(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &book{ID: "a", Title: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	err := c.Create(ctx, &book{ID: "a", Title: "second"})
+	if !errors.Is(err, cloudfirestore.ErrAlreadyExists) {
+		t.Fatalf("Create = %v, want ErrAlreadyExists", err)
+	}
+	b := &book{ID: "a"}
+	if err := c.Get(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Title != "first" {
+		t.Errorf("Title = %q, want %q", b.Title, "first")
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Get(ctx, &book{ID: "missing"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+	err := c.Update(ctx, &book{ID: "missing"}, cloudfirestore.FieldUpdate{Path: "Title", Value: "x"})
+	if !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Update = %v, want ErrNotFound", err)
+	}
+	errs, err := c.GetAll(ctx, []cloudfirestore.Pathable{&book{ID: "missing"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(errs[0], cloudfirestore.ErrNotFound) {
+		t.Errorf("GetAll = %v, want ErrNotFound", errs[0])
+	}
+}
+
+func TestTransactionConflict(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Set(ctx, &book{ID: "a", Year: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A write between the read and the commit makes the transaction retry.
+	attempts := 0
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		attempts++
+		b := &book{ID: "a"}
+		if err := tx.Get(ctx, b); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			if err := c.Set(ctx, &book{ID: "a", Year: 10}); err != nil {
+				return err
+			}
+		}
+		b.Year++
+		return tx.Set(ctx, b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	b := &book{ID: "a"}
+	if err := c.Get(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Year != 11 {
+		t.Errorf("Year = %d, want 11", b.Year)
+	}
+
+	err = c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		if err := tx.Get(ctx, &book{ID: "a"}); err != nil {
+			return err
+		}
+		if err := c.Delete(ctx, &book{ID: "a"}); err != nil {
+			return err
+		}
+		return tx.Set(ctx, &book{ID: "a", Year: 1})
+	}, cloudfirestore.MaxAttempts(1))
+	if !errors.Is(err, cloudfirestore.ErrContention) {
+		t.Fatalf("RunTransaction = %v, want ErrContention", err)
+	}
+	if err := c.Get(ctx, &book{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTransactionQueryConflict(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	q := c.Collection("books").Where("Year", "==", 2000)
+
+	// A document appearing in the query result before the commit is a conflict.
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		if _, err := tx.Query(ctx, q, func(context.Context, *cloudfirestore.Document) error { return nil }); err != nil {
+			return err
+		}
+		if err := c.Create(ctx, &book{ID: "phantom", Year: 2000}); err != nil {
+			return err
+		}
+		return tx.Set(ctx, &book{ID: "a"})
+	}, cloudfirestore.MaxAttempts(1))
+	if !errors.Is(err, cloudfirestore.ErrContention) {
+		t.Fatalf("RunTransaction = %v, want ErrContention", err)
+	}
+}
+
+func TestQueryOrderAndCursors(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	for _, b := range []*book{
+		{ID: "c", Year: 2003},
+		{ID: "a", Year: 2001},
+		{ID: "e", Year: 2005},
+		{ID: "b", Year: 2002},
+		{ID: "d", Year: 2004},
+	} {
+		if err := c.Create(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := c.Collection("books")
+
+	tests := []struct {
+		name string
+		q    cloudfirestore.Query
+		want []string
+	}{
+		{"asc", base.OrderBy("Year", cloudfirestore.Asc), []string{"a", "b", "c", "d", "e"}},
+		{"desc", base.OrderBy("Year", cloudfirestore.Desc), []string{"e", "d", "c", "b", "a"}},
+		{"default name order", base, []string{"a", "b", "c", "d", "e"}},
+		{"limit", base.OrderBy("Year", cloudfirestore.Asc).Limit(2), []string{"a", "b"}},
+		{"offset", base.OrderBy("Year", cloudfirestore.Asc).Offset(3), []string{"d", "e"}},
+		{"limit to last", base.OrderBy("Year", cloudfirestore.Asc).LimitToLast(2), []string{"d", "e"}},
+		{"start at", base.OrderBy("Year", cloudfirestore.Asc).StartAt(2003), []string{"c", "d", "e"}},
+		{"start after", base.OrderBy("Year", cloudfirestore.Asc).StartAfter(2003), []string{"d", "e"}},
+		{"end at", base.OrderBy("Year", cloudfirestore.Asc).EndAt(2002), []string{"a", "b"}},
+		{"end before", base.OrderBy("Year", cloudfirestore.Asc).EndBefore(2002), []string{"a"}},
+		{"desc start after", base.OrderBy("Year", cloudfirestore.Desc).StartAfter(2003).Limit(1), []string{"b"}},
+		{"where", base.Where("Year", ">", 2003).OrderBy("Year", cloudfirestore.Desc), []string{"e", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			if _, err := c.Sequence(ctx, tt.q, func(_ context.Context, doc *cloudfirestore.Document) error {
+				got = append(got, doc.ID)
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAllPerItem(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	for _, b := range []*book{{ID: "a", Title: "A"}, {ID: "c", Title: "C"}} {
+		if err := c.Create(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := []cloudfirestore.Pathable{&book{ID: "a"}, &book{ID: "b"}, &book{ID: "c"}}
+	errs, err := c.GetAll(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != len(data) {
+		t.Fatalf("got %d results, want %d", len(errs), len(data))
+	}
+	for idx, want := range []string{"A", "", "C"} {
+		if want == "" {
+			var oe *cloudfirestore.OpError
+			if !errors.Is(errs[idx], cloudfirestore.ErrNotFound) || !errors.As(errs[idx], &oe) || oe.Path != "books/b" {
+				t.Errorf("errs[%d] = %v, want ErrNotFound for books/b", idx, errs[idx])
+			}
+			continue
+		}
+		if errs[idx] != nil {
+			t.Errorf("errs[%d] = %v, want nil", idx, errs[idx])
+		}
+		if got := data[idx].(*book).Title; got != want {
+			t.Errorf("Title[%d] = %q, want %q", idx, got, want)
+		}
+	}
+}
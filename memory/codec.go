@@ -0,0 +1,366 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encode converts a struct or map into the stored representation, following
+// the same `firestore` struct tags the SDK uses.
+func encode(data any) (map[string]any, error) {
+	rv := reflect.ValueOf(data)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("memory: cannot store nil %T", data)
+		}
+		rv = rv.Elem()
+	}
+	v, err := encodeValue(rv)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("memory: cannot store %T as a document", data)
+	}
+	return m, nil
+}
+
+func encodeValue(rv reflect.Value) (any, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if t, ok := rv.Interface().(cloudfirestore.Transform); ok {
+		return t, nil
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(rv.Elem())
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append([]byte(nil), rv.Bytes()...), nil
+		}
+		fallthrough
+	case reflect.Array:
+		ret := make([]any, rv.Len())
+		for i := range ret {
+			v, err := encodeValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = v
+		}
+		return ret, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("memory: map key must be string, got %s", rv.Type().Key())
+		}
+		ret := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			v, err := encodeValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			ret[iter.Key().String()] = v
+		}
+		return ret, nil
+	case reflect.Struct:
+		if rv.Type() == timeType {
+			return rv.Interface(), nil
+		}
+		ret := map[string]any{}
+		if err := encodeStruct(rv, ret); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+	// Other SDK values such as *latlng.LatLng are stored as is.
+	return rv.Interface(), nil
+}
+
+func encodeStruct(rv reflect.Value, ret map[string]any) error {
+	for _, f := range fieldsOf(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.flatten {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if err := encodeStruct(fv, ret); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.serverTimestamp && fv.IsZero() {
+			ret[f.name] = cloudfirestore.ServerTimestamp
+			continue
+		}
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		v, err := encodeValue(fv)
+		if err != nil {
+			return fmt.Errorf("memory: field %s: %w", f.name, err)
+		}
+		ret[f.name] = v
+	}
+	return nil
+}
+
+type field struct {
+	name            string
+	index           []int
+	flatten         bool
+	omitEmpty       bool
+	serverTimestamp bool
+}
+
+func fieldsOf(t reflect.Type) []field {
+	var ret []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("firestore")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			ret = append(ret, field{index: sf.Index, flatten: true})
+			continue
+		}
+		// Like the SDK, embedded pointers are flattened too, unless the
+		// struct type is unexported.
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct {
+			if sf.IsExported() {
+				ret = append(ret, field{index: sf.Index, flatten: true})
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := field{name: name, index: sf.Index}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			case "serverTimestamp":
+				f.serverTimestamp = true
+			}
+		}
+		ret = append(ret, f)
+	}
+	return ret
+}
+
+// decode populates the struct or map pointed to by data, like DataTo.
+func decode(m map[string]any, data any) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("memory: decode needs a non-nil pointer, got %T", data)
+	}
+	return decodeValue(m, rv.Elem())
+}
+
+func decodeValue(v any, rv reflect.Value) error {
+	if v == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(copyValue(v)))
+		return nil
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decodeValue(v, rv.Elem())
+	case reflect.Bool:
+		if b, ok := v.(bool); ok {
+			rv.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := v.(int64); ok {
+			if rv.OverflowInt(n) {
+				return fmt.Errorf("memory: %d overflows %s", n, rv.Type())
+			}
+			rv.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, ok := v.(int64); ok {
+			if n < 0 || rv.OverflowUint(uint64(n)) {
+				return fmt.Errorf("memory: %d overflows %s", n, rv.Type())
+			}
+			rv.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch n := v.(type) {
+		case float64:
+			rv.SetFloat(n)
+			return nil
+		case int64:
+			rv.SetFloat(float64(n))
+			return nil
+		}
+	case reflect.String:
+		if s, ok := v.(string); ok {
+			rv.SetString(s)
+			return nil
+		}
+	case reflect.Slice:
+		if b, ok := v.([]byte); ok && rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte(nil), b...))
+			return nil
+		}
+		if a, ok := v.([]any); ok {
+			s := reflect.MakeSlice(rv.Type(), len(a), len(a))
+			for i, e := range a {
+				if err := decodeValue(e, s.Index(i)); err != nil {
+					return err
+				}
+			}
+			rv.Set(s)
+			return nil
+		}
+	case reflect.Array:
+		if a, ok := v.([]any); ok {
+			for i := 0; i < rv.Len(); i++ {
+				var e any
+				if i < len(a) {
+					e = a[i]
+				}
+				if err := decodeValue(e, rv.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		if m, ok := v.(map[string]any); ok && rv.Type().Key().Kind() == reflect.String {
+			if rv.IsNil() {
+				rv.Set(reflect.MakeMapWithSize(rv.Type(), len(m)))
+			}
+			for k, e := range m {
+				ev := reflect.New(rv.Type().Elem()).Elem()
+				if err := decodeValue(e, ev); err != nil {
+					return err
+				}
+				rv.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), ev)
+			}
+			return nil
+		}
+	case reflect.Struct:
+		if m, ok := v.(map[string]any); ok && rv.Type() != timeType {
+			return decodeStruct(m, rv)
+		}
+	}
+	if vv := reflect.ValueOf(v); vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return nil
+	}
+	return fmt.Errorf("memory: cannot set %T into %s", v, rv.Type())
+}
+
+func decodeStruct(m map[string]any, rv reflect.Value) error {
+	for _, f := range fieldsOf(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		if f.flatten {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if err := decodeStruct(m, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		v, ok := m[f.name]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(v, fv); err != nil {
+			return fmt.Errorf("memory: field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// copyValue deep copies a stored value so callers never share its maps.
+func copyValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(t))
+		for k, e := range t {
+			ret[k] = copyValue(e)
+		}
+		return ret
+	case []any:
+		ret := make([]any, len(t))
+		for i, e := range t {
+			ret[i] = copyValue(e)
+		}
+		return ret
+	case []byte:
+		return append([]byte(nil), t...)
+	}
+	return v
+}
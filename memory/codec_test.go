@@ -0,0 +1,84 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type Base struct {
+	Name string
+}
+
+type embedding struct {
+	*Base
+	ID    string `firestore:"-"`
+	Count int
+}
+
+func (e *embedding) Path(context.Context) string {
+	return "embedding/" + e.ID
+}
+
+func TestCodecEmbeddedPointer(t *testing.T) {
+	m, err := encode(&embedding{Base: &Base{Name: "x"}, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"Name": "x", "Count": int64(1)}; !reflect.DeepEqual(m, want) {
+		t.Errorf("encode = %v, want %v", m, want)
+	}
+
+	m, err = encode(&embedding{Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"Count": int64(1)}; !reflect.DeepEqual(m, want) {
+		t.Errorf("encode with nil embedded = %v, want %v", m, want)
+	}
+
+	var got embedding
+	if err := decode(map[string]any{"Name": "y", "Count": int64(2)}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Base == nil || got.Name != "y" || got.Count != 2 {
+		t.Errorf("decode = %+v, want Name y and Count 2", got)
+	}
+}
+
+func TestQueryEmbeddedPointerField(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	if err := c.Set(ctx, &embedding{Base: &Base{Name: "x"}, ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := c.Count(ctx, c.Collection("embedding").Where("Name", "==", "x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("Count = %d, want 1", n)
+	}
+}
@@ -0,0 +1,73 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+func (i *inner) Exists(ctx context.Context, data cloudfirestore.Pathable) (bool, error) {
+	found, err := existsAll(ctx, "Exists", []cloudfirestore.Pathable{data}, func(path string) (*document, error) {
+		return i.store.get(path), nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return found[0], nil
+}
+
+func (i *inner) ExistsAll(ctx context.Context, data []cloudfirestore.Pathable) ([]bool, error) {
+	return existsAll(ctx, "ExistsAll", data, func(path string) (*document, error) {
+		return i.store.get(path), nil
+	})
+}
+
+func (i *innerTran) Exists(ctx context.Context, data cloudfirestore.Pathable) (bool, error) {
+	found, err := existsAll(ctx, "Exists", []cloudfirestore.Pathable{data}, i.read)
+	if err != nil {
+		return false, err
+	}
+	return found[0], nil
+}
+
+func (i *innerTran) ExistsAll(ctx context.Context, data []cloudfirestore.Pathable) ([]bool, error) {
+	return existsAll(ctx, "ExistsAll", data, i.read)
+}
+
+func existsAll(ctx context.Context, op string, data []cloudfirestore.Pathable, get func(path string) (*document, error)) ([]bool, error) {
+	found := make([]bool, len(data))
+	for idx, d := range data {
+		path := d.Path(ctx)
+		if err := cloudfirestore.ValidatePath(d, path); err != nil {
+			return nil, &cloudfirestore.OpError{Op: op, Path: path, Err: err}
+		}
+		doc, err := get(path)
+		if err != nil {
+			return nil, &cloudfirestore.OpError{Op: op, Path: path, Err: err}
+		}
+		found[idx] = doc != nil
+	}
+	return found, nil
+}
@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+var errHook = errors.New("hook failed")
+
+type hooked struct {
+	ID     string `firestore:"-"`
+	Title  string
+	Loaded bool `firestore:"-"`
+	Fail   bool `firestore:"-"`
+}
+
+func (h *hooked) Path(context.Context) string {
+	return "hooked/" + h.ID
+}
+
+func (h *hooked) hook() error {
+	if h.Fail {
+		return errHook
+	}
+	return nil
+}
+
+func (h *hooked) BeforeCreate(context.Context) error { return h.hook() }
+func (h *hooked) BeforeSet(context.Context) error    { return h.hook() }
+func (h *hooked) BeforeDelete(context.Context) error { return h.hook() }
+
+func (h *hooked) AfterGet(context.Context) error {
+	h.Loaded = true
+	return h.hook()
+}
+
+func TestHooksAbort(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Create = %v, want the hook error", err)
+	}
+	if err := c.Set(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Set = %v, want the hook error", err)
+	}
+	if err := c.Get(ctx, &hooked{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+
+	if err := c.Create(ctx, &hooked{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Delete = %v, want the hook error", err)
+	}
+	if err := c.Get(ctx, &hooked{ID: "a"}); err != nil {
+		t.Errorf("Get after aborted Delete = %v", err)
+	}
+}
+
+func TestAfterGet(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &hooked{ID: "a", Title: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	got := &hooked{ID: "a"}
+	if err := c.Get(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Loaded || got.Title != "A" {
+		t.Errorf("Get = %+v, want AfterGet to run on the loaded data", got)
+	}
+	if err := c.Get(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Get = %v, want the hook error", err)
+	}
+
+	items := []cloudfirestore.Pathable{&hooked{ID: "a"}, &hooked{ID: "a", Fail: true}}
+	errs, err := c.GetAll(ctx, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs[0] != nil || !items[0].(*hooked).Loaded {
+		t.Errorf("GetAll item 0 = %v, want AfterGet to run", errs[0])
+	}
+	if !errors.Is(errs[1], errHook) {
+		t.Errorf("GetAll item 1 = %v, want the hook error", errs[1])
+	}
+}
+
+func TestBatchHooks(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &hooked{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	b := c.Batch()
+	if err := b.Create(ctx, &hooked{ID: "b", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Create = %v, want the hook error", err)
+	}
+	if err := b.Set(ctx, &hooked{ID: "c", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Set = %v, want the hook error", err)
+	}
+	if err := b.Delete(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Delete = %v, want the hook error", err)
+	}
+	if err := b.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	assertHooked(ctx, t, c)
+}
+
+func TestBulkHooks(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &hooked{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	b := c.Bulk(ctx)
+	if err := b.Create(ctx, &hooked{ID: "b", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Create = %v, want the hook error", err)
+	}
+	if err := b.Set(ctx, &hooked{ID: "c", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Set = %v, want the hook error", err)
+	}
+	if err := b.Delete(ctx, &hooked{ID: "a", Fail: true}); !errors.Is(err, errHook) {
+		t.Errorf("Delete = %v, want the hook error", err)
+	}
+	summary, err := b.End(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Succeeded+summary.Failed != 0 {
+		t.Errorf("End = %+v, want no queued writes", summary)
+	}
+	assertHooked(ctx, t, c)
+}
+
+// assertHooked checks that only the document "a" exists.
+func assertHooked(ctx context.Context, t *testing.T, c cloudfirestore.CloudFirestore) {
+	t.Helper()
+	exists, err := c.ExistsAll(ctx, []cloudfirestore.Pathable{&hooked{ID: "a"}, &hooked{ID: "b"}, &hooked{ID: "c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists[0] || exists[1] || exists[2] {
+		t.Errorf("ExistsAll = %v, want [true false false]", exists)
+	}
+}
@@ -0,0 +1,491 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/Eigen438/cloudfirestore"
+	"google.golang.org/genproto/googleapis/type/latlng"
+)
+
+// docPath is the value of the DocumentID field, ordered like a reference.
+type docPath string
+
+type snapshot struct {
+	data map[string]any
+}
+
+func (s *snapshot) DataTo(p any) error {
+	return decode(s.data, p)
+}
+
+func (s *snapshot) Data() map[string]any {
+	return copyValue(s.data).(map[string]any)
+}
+
+// NewDocument builds a Document from a struct or map, for example to return
+// canned results from the mock package.
+func NewDocument(path string, data any) (*cloudfirestore.Document, error) {
+	m, err := encode(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveTransforms(m, nil, time.Now()); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return newDocument(path, &document{data: m, createTime: now, updateTime: now}, now), nil
+}
+
+// newDocument copies d into a Document.
+func newDocument(path string, d *document, readTime time.Time) *cloudfirestore.Document {
+	return &cloudfirestore.Document{
+		ID:           path[strings.LastIndex(path, "/")+1:],
+		Path:         path,
+		CreateTime:   d.createTime,
+		UpdateTime:   d.updateTime,
+		ReadTime:     readTime,
+		DocumentData: &snapshot{data: copyValue(d.data).(map[string]any)},
+	}
+}
+
+type result struct {
+	path string
+	doc  *document
+	keys []any
+}
+
+// query evaluates q against the current documents.
+func (s *store) query(q cloudfirestore.Query) ([]*cloudfirestore.Document, error) {
+	results, _, err := s.evaluate(q)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	docs := make([]*cloudfirestore.Document, len(results))
+	for idx, r := range results {
+		docs[idx] = newDocument(r.path, r.doc, now)
+	}
+	return docs, nil
+}
+
+// evaluate returns the matching documents in query order, and a channel
+// closed on the next commit. The documents must not be modified.
+func (s *store) evaluate(q cloudfirestore.Query) ([]result, <-chan struct{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results, err := s.evaluateLocked(q)
+	return results, s.changed, err
+}
+
+// evaluateLocked is evaluate with s.mu held.
+func (s *store) evaluateLocked(q cloudfirestore.Query) ([]result, error) {
+	spec := q.Spec()
+	filters := make([]cloudfirestore.Filter, len(spec.Filters))
+	for idx, f := range spec.Filters {
+		v, err := queryValue(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		filters[idx] = cloudfirestore.Filter{Path: f.Path, Op: f.Op, Value: v}
+	}
+	orders := effectiveOrders(spec.Orders, filters)
+
+	var results []result
+	for path, d := range s.docs {
+		if !inCollection(spec, path) {
+			continue
+		}
+		ok, err := matches(filters, path, d.data)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		keys := make([]any, len(orders))
+		found := true
+		for idx, o := range orders {
+			keys[idx], found = fieldValue(path, d.data, o.Path)
+			if !found {
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		results = append(results, result{path: path, doc: d, keys: keys})
+	}
+
+	slices.SortFunc(results, func(a, b result) int {
+		return compareKeys(orders, a.keys, b.keys)
+	})
+
+	if spec.Start != nil {
+		start, err := cursorValues(orders, spec.Start)
+		if err != nil {
+			return nil, err
+		}
+		results = slices.DeleteFunc(results, func(r result) bool {
+			c := compareKeys(orders, r.keys, start)
+			return c < 0 || (c == 0 && !spec.Start.Inclusive)
+		})
+	}
+	if spec.End != nil {
+		end, err := cursorValues(orders, spec.End)
+		if err != nil {
+			return nil, err
+		}
+		results = slices.DeleteFunc(results, func(r result) bool {
+			c := compareKeys(orders, r.keys, end)
+			return c > 0 || (c == 0 && !spec.End.Inclusive)
+		})
+	}
+	if spec.Offset > 0 {
+		results = results[min(spec.Offset, len(results)):]
+	}
+	if spec.Limit > 0 && len(results) > spec.Limit {
+		if spec.LimitToLast {
+			results = results[len(results)-spec.Limit:]
+		} else {
+			results = results[:spec.Limit]
+		}
+	}
+
+	return results, nil
+}
+
+func inCollection(spec cloudfirestore.QuerySpec, path string) bool {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return false
+	}
+	collection := path[:idx]
+	if !spec.AllDescendants {
+		return collection == spec.Collection
+	}
+	return collection[strings.LastIndex(collection, "/")+1:] == spec.Collection
+}
+
+// effectiveOrders adds the implicit orders Firestore applies: the first
+// inequality field when no order is given, and the document name last.
+func effectiveOrders(orders []cloudfirestore.Order, filters []cloudfirestore.Filter) []cloudfirestore.Order {
+	ret := slices.Clone(orders)
+	if len(ret) == 0 {
+		for _, f := range filters {
+			switch f.Op {
+			case "<", "<=", ">", ">=", "!=", "not-in":
+				ret = append(ret, cloudfirestore.Order{Path: f.Path, Direction: cloudfirestore.Asc})
+			}
+			if len(ret) > 0 {
+				break
+			}
+		}
+	}
+	dir := cloudfirestore.Asc
+	if len(ret) > 0 {
+		if ret[len(ret)-1].Path == cloudfirestore.DocumentID {
+			return ret
+		}
+		dir = ret[len(ret)-1].Direction
+	}
+	return append(ret, cloudfirestore.Order{Path: cloudfirestore.DocumentID, Direction: dir})
+}
+
+func compareKeys(orders []cloudfirestore.Order, a, b []any) int {
+	for idx := range min(len(a), len(b)) {
+		c := compareValues(a[idx], b[idx])
+		if orders[idx].Direction == cloudfirestore.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func cursorValues(orders []cloudfirestore.Order, c *cloudfirestore.Cursor) ([]any, error) {
+	values := make([]any, len(c.Values))
+	for idx, v := range c.Values {
+		qv, err := queryValue(v)
+		if err != nil {
+			return nil, err
+		}
+		if idx < len(orders) && orders[idx].Path == cloudfirestore.DocumentID {
+			qv = asDocPath(qv)
+		}
+		values[idx] = qv
+	}
+	return values, nil
+}
+
+// queryValue normalises a filter or cursor value like a stored value.
+func queryValue(v any) (any, error) {
+	return encodeValue(reflect.ValueOf(v))
+}
+
+func fieldValue(path string, data map[string]any, field string) (any, bool) {
+	if field == cloudfirestore.DocumentID {
+		return docPath(path), true
+	}
+	var v any = data
+	for _, key := range strings.Split(field, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func matches(filters []cloudfirestore.Filter, path string, data map[string]any) (bool, error) {
+	for _, f := range filters {
+		v, ok := fieldValue(path, data, f.Path)
+		if !ok {
+			return false, nil
+		}
+		want := f.Value
+		if f.Path == cloudfirestore.DocumentID {
+			want = asDocPath(want)
+		}
+		ok, err := match(f.Op, v, want)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func asDocPath(v any) any {
+	switch t := v.(type) {
+	case string:
+		return docPath(t)
+	case []any:
+		ret := make([]any, len(t))
+		for idx, e := range t {
+			ret[idx] = asDocPath(e)
+		}
+		return ret
+	}
+	return v
+}
+
+func match(op string, v, want any) (bool, error) {
+	switch op {
+	case "==":
+		return equalValues(v, want), nil
+	case "!=":
+		return v != nil && !equalValues(v, want), nil
+	case "<", "<=", ">", ">=":
+		if typeOrder(v) != typeOrder(want) {
+			return false, nil
+		}
+		c := compareValues(v, want)
+		switch op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "array-contains":
+		arr, _ := v.([]any)
+		return containsValue(arr, want), nil
+	case "array-contains-any":
+		arr, _ := v.([]any)
+		wants, ok := want.([]any)
+		if !ok {
+			return false, fmt.Errorf("memory: %s needs a list, got %T", op, want)
+		}
+		for _, w := range wants {
+			if containsValue(arr, w) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "in", "not-in":
+		wants, ok := want.([]any)
+		if !ok {
+			return false, fmt.Errorf("memory: %s needs a list, got %T", op, want)
+		}
+		if op == "in" {
+			return containsValue(wants, v), nil
+		}
+		return v != nil && !containsValue(wants, v), nil
+	}
+	return false, fmt.Errorf("memory: unknown operator %q", op)
+}
+
+func containsValue(arr []any, v any) bool {
+	for _, a := range arr {
+		if equalValues(a, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalValues(a, b any) bool {
+	return typeOrder(a) == typeOrder(b) && compareValues(a, b) == 0
+}
+
+// typeOrder ranks value types in Firestore's cross-type sort order.
+func typeOrder(v any) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case int64, float64:
+		return 2
+	case time.Time:
+		return 3
+	case string:
+		return 4
+	case []byte:
+		return 5
+	case docPath, *firestore.DocumentRef:
+		return 6
+	case *latlng.LatLng:
+		return 7
+	case []any:
+		return 8
+	case map[string]any:
+		return 9
+	}
+	return 10
+}
+
+func compareValues(a, b any) int {
+	if c := typeOrder(a) - typeOrder(b); c != 0 {
+		return c
+	}
+	switch x := a.(type) {
+	case bool:
+		y := b.(bool)
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	case int64, float64:
+		if xi, ok := x.(int64); ok {
+			if yi, ok := b.(int64); ok {
+				return cmp.Compare(xi, yi)
+			}
+		}
+		return compareFloats(toFloat(a), toFloat(b))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	case string:
+		return strings.Compare(x, b.(string))
+	case []byte:
+		return bytes.Compare(x, b.([]byte))
+	case docPath, *firestore.DocumentRef:
+		return slices.Compare(strings.Split(refPath(a), "/"), strings.Split(refPath(b), "/"))
+	case *latlng.LatLng:
+		y := b.(*latlng.LatLng)
+		if c := compareFloats(x.GetLatitude(), y.GetLatitude()); c != 0 {
+			return c
+		}
+		return compareFloats(x.GetLongitude(), y.GetLongitude())
+	case []any:
+		y := b.([]any)
+		for idx := range min(len(x), len(y)) {
+			if c := compareValues(x[idx], y[idx]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(x), len(y))
+	case map[string]any:
+		y := b.(map[string]any)
+		xk, yk := sortedKeys(x), sortedKeys(y)
+		for idx := range min(len(xk), len(yk)) {
+			if c := strings.Compare(xk[idx], yk[idx]); c != 0 {
+				return c
+			}
+			if c := compareValues(x[xk[idx]], y[yk[idx]]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(xk), len(yk))
+	}
+	return 0
+}
+
+func refPath(v any) string {
+	if r, ok := v.(*firestore.DocumentRef); ok {
+		const sep = "/documents/"
+		if idx := strings.Index(r.Path, sep); idx >= 0 {
+			return r.Path[idx+len(sep):]
+		}
+		return r.Path
+	}
+	return string(v.(docPath))
+}
+
+func toFloat(v any) float64 {
+	if i, ok := v.(int64); ok {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+// compareFloats orders NaN before every other number, as Firestore does.
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	}
+	return cmp.Compare(a, b)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,385 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+type document struct {
+	data       map[string]any
+	version    int64
+	createTime time.Time
+	updateTime time.Time
+}
+
+// write stages one change. It receives the current document (nil when
+// missing) and returns the new document data (nil to delete).
+type write struct {
+	op    string
+	path  string
+	apply func(current *document) (map[string]any, error)
+	// committed, when set, receives the update time of the commit.
+	committed func(updateTime time.Time)
+}
+
+type store struct {
+	mu      sync.Mutex
+	docs    map[string]*document
+	version int64
+	// last is the time of the last commit; update times always increase so
+	// they can serve as versions.
+	last time.Time
+	// changed is closed and replaced on every commit, to wake watchers.
+	changed chan struct{}
+}
+
+func newStore() *store {
+	return &store{
+		docs:    map[string]*document{},
+		changed: make(chan struct{}),
+	}
+}
+
+// get returns a copy of the document, or nil when it doesn't exist.
+func (s *store) get(path string) *document {
+	d, _ := s.getWatch(path)
+	return d
+}
+
+// getWatch is get that also returns a channel closed on the next commit.
+func (s *store) getWatch(path string) (*document, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.docs[path]
+	if !ok {
+		return nil, s.changed
+	}
+	return &document{
+		data:       copyValue(d.data).(map[string]any),
+		version:    d.version,
+		createTime: d.createTime,
+		updateTime: d.updateTime,
+	}, s.changed
+}
+
+// queryRead records the result of a query run in a transaction.
+type queryRead struct {
+	q     cloudfirestore.Query
+	paths []string
+}
+
+// commit applies writes atomically after checking that none of the
+// documents in reads changed since the recorded version, and that the
+// queries still return the same documents.
+func (s *store) commit(reads map[string]int64, writes []write, queries ...queryRead) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for path, version := range reads {
+		var current int64
+		if d, ok := s.docs[path]; ok {
+			current = d.version
+		}
+		if current != version {
+			return &cloudfirestore.OpError{Op: "Commit", Path: path, Err: cloudfirestore.ErrContention}
+		}
+	}
+	for _, qr := range queries {
+		results, err := s.evaluateLocked(qr.q)
+		if err != nil {
+			return &cloudfirestore.OpError{Op: "Commit", Err: err}
+		}
+		if !slices.EqualFunc(results, qr.paths, func(r result, path string) bool { return r.path == path }) {
+			return &cloudfirestore.OpError{Op: "Commit", Err: cloudfirestore.ErrContention}
+		}
+	}
+
+	now := time.Now()
+	if !now.After(s.last) {
+		now = s.last.Add(time.Nanosecond)
+	}
+	staged := map[string]*document{}
+	order := []string{}
+	for _, w := range writes {
+		current, ok := staged[w.path]
+		if !ok {
+			current = s.docs[w.path]
+			order = append(order, w.path)
+		}
+		data, err := w.apply(current)
+		if err != nil {
+			return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: err}
+		}
+		if data == nil {
+			staged[w.path] = nil
+			continue
+		}
+		if err := resolveTransforms(data, current, now); err != nil {
+			return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: err}
+		}
+		d := &document{data: data, createTime: now, updateTime: now}
+		if current != nil {
+			d.createTime = current.createTime
+		}
+		staged[w.path] = d
+	}
+
+	for _, path := range order {
+		d := staged[path]
+		if d == nil {
+			delete(s.docs, path)
+			continue
+		}
+		s.version++
+		d.version = s.version
+		s.docs[path] = d
+	}
+	if len(order) > 0 {
+		s.last = now
+		close(s.changed)
+		s.changed = make(chan struct{})
+	}
+	for _, w := range writes {
+		if w.committed != nil {
+			w.committed(now)
+		}
+	}
+	return nil
+}
+
+// deletePaths deletes the documents, and with opts.Recursive everything
+// below them, children first.
+func (s *store) deletePaths(paths []string, opts *cloudfirestore.DeleteOptions) (int, error) {
+	var targets []string
+	for _, path := range paths {
+		if opts.Recursive {
+			targets = append(targets, s.descendants(path)...)
+		}
+		targets = append(targets, path)
+	}
+
+	deleted := 0
+	for _, path := range targets {
+		if opts.DryRun != nil {
+			*opts.DryRun = append(*opts.DryRun, path)
+		} else if err := s.commit(nil, []write{deleteWrite(path)}); err != nil {
+			return deleted, err
+		}
+		deleted++
+		if opts.Progress != nil {
+			opts.Progress(cloudfirestore.DeleteProgress{Deleted: deleted, Path: path})
+		}
+	}
+	return deleted, nil
+}
+
+// descendants returns the documents below path, deepest first.
+func (s *store) descendants(path string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ret []string
+	for p := range s.docs {
+		if strings.HasPrefix(p, path+"/") {
+			ret = append(ret, p)
+		}
+	}
+	slices.SortFunc(ret, func(a, b string) int {
+		if c := strings.Count(b, "/") - strings.Count(a, "/"); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return ret
+}
+
+func createWrite(path string, data map[string]any) write {
+	return write{op: "Create", path: path, apply: func(current *document) (map[string]any, error) {
+		if current != nil {
+			return nil, cloudfirestore.ErrAlreadyExists
+		}
+		return copyValue(data).(map[string]any), nil
+	}}
+}
+
+func setWrite(path string, data map[string]any) write {
+	return write{op: "Set", path: path, apply: func(*document) (map[string]any, error) {
+		return copyValue(data).(map[string]any), nil
+	}}
+}
+
+func deleteWrite(path string) write {
+	return write{op: "Delete", path: path, apply: func(*document) (map[string]any, error) {
+		return nil, nil
+	}}
+}
+
+func updateWrite(path string, updates []cloudfirestore.FieldUpdate) (write, error) {
+	values := make([]any, len(updates))
+	for i, u := range updates {
+		if u.Path == "" {
+			return write{}, fmt.Errorf("memory: empty field path")
+		}
+		v, err := encodeValue(reflect.ValueOf(u.Value))
+		if err != nil {
+			return write{}, err
+		}
+		values[i] = v
+	}
+	return write{op: "Update", path: path, apply: func(current *document) (map[string]any, error) {
+		if current == nil {
+			return nil, cloudfirestore.ErrNotFound
+		}
+		data := copyValue(current.data).(map[string]any)
+		for i, u := range updates {
+			setField(data, strings.Split(u.Path, "."), copyValue(values[i]))
+		}
+		return data, nil
+	}}, nil
+}
+
+func setField(data map[string]any, keys []string, v any) {
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := data[k].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			data[k] = next
+		}
+		data = next
+	}
+	data[keys[len(keys)-1]] = v
+}
+
+// resolveTransforms replaces Transform sentinels with their stored value.
+func resolveTransforms(data map[string]any, current *document, now time.Time) error {
+	var prev map[string]any
+	if current != nil {
+		prev = current.data
+	}
+	return resolveMap(data, prev, now)
+}
+
+func resolveMap(data, prev map[string]any, now time.Time) error {
+	for k, v := range data {
+		switch t := v.(type) {
+		case map[string]any:
+			p, _ := prev[k].(map[string]any)
+			if err := resolveMap(t, p, now); err != nil {
+				return err
+			}
+		case cloudfirestore.Transform:
+			v, keep, err := applyTransform(t, prev[k], now)
+			if err != nil {
+				return fmt.Errorf("memory: field %s: %w", k, err)
+			}
+			if !keep {
+				delete(data, k)
+				continue
+			}
+			data[k] = v
+		}
+	}
+	return nil
+}
+
+func applyTransform(t cloudfirestore.Transform, prev any, now time.Time) (any, bool, error) {
+	switch t.Kind {
+	case cloudfirestore.TransformServerTimestamp:
+		return now, true, nil
+	case cloudfirestore.TransformDelete:
+		return nil, false, nil
+	case cloudfirestore.TransformIncrement:
+		if len(t.Values) != 1 {
+			return nil, false, fmt.Errorf("increment needs exactly one value, got %d", len(t.Values))
+		}
+		n, err := encodeValue(reflect.ValueOf(t.Values[0]))
+		if err != nil {
+			return nil, false, err
+		}
+		return increment(prev, n)
+	case cloudfirestore.TransformArrayUnion, cloudfirestore.TransformArrayRemove:
+		arr, _ := prev.([]any)
+		arr = copyValue(arr).([]any)
+		for _, e := range t.Values {
+			ev, err := encodeValue(reflect.ValueOf(e))
+			if err != nil {
+				return nil, false, err
+			}
+			arr = applyArray(t.Kind, arr, ev)
+		}
+		if arr == nil {
+			arr = []any{}
+		}
+		return arr, true, nil
+	}
+	return nil, false, fmt.Errorf("unknown transform %d", t.Kind)
+}
+
+func increment(prev, n any) (any, bool, error) {
+	switch d := n.(type) {
+	case int64:
+		switch p := prev.(type) {
+		case int64:
+			return p + d, true, nil
+		case float64:
+			return p + float64(d), true, nil
+		}
+		return d, true, nil
+	case float64:
+		switch p := prev.(type) {
+		case int64:
+			return float64(p) + d, true, nil
+		case float64:
+			return p + d, true, nil
+		}
+		return d, true, nil
+	}
+	return nil, false, fmt.Errorf("increment needs a number, got %T", n)
+}
+
+func applyArray(kind cloudfirestore.TransformKind, arr []any, e any) []any {
+	if kind == cloudfirestore.TransformArrayUnion {
+		for _, a := range arr {
+			if reflect.DeepEqual(a, e) {
+				return arr
+			}
+		}
+		return append(arr, e)
+	}
+	ret := arr[:0]
+	for _, a := range arr {
+		if !reflect.DeepEqual(a, e) {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,238 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+var (
+	errReadAfterWrite = errors.New("memory: read after write in transaction")
+	errReadOnly       = errors.New("memory: write in read-only transaction")
+)
+
+type innerTran struct {
+	store *store
+	reads map[string]int64
+	// snapshots holds the documents read in the transaction, nil for
+	// missing ones.
+	snapshots map[string]*document
+	queries   []queryRead
+	writes    []write
+	hooks     []func(context.Context)
+	readOnly  bool
+}
+
+func (i *innerTran) AfterCommit(f func(context.Context)) {
+	i.hooks = append(i.hooks, f)
+}
+
+func (i *innerTran) Create(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Create", Err: cloudfirestore.ErrNotPathable}
+	}
+	cloudfirestore.AllocateID(ctx, data)
+	if err := cloudfirestore.BeforeCreate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Create", Path: path, Err: err}
+	}
+	return i.writeVersioned(createWrite(path, m), data)
+}
+
+func (i *innerTran) Set(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Set", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeSet(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	if err := cloudfirestore.Validate(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	m, err := encode(data)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Set", Path: path, Err: err}
+	}
+	return i.writeVersioned(setWrite(path, m), data)
+}
+
+func (i *innerTran) Get(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Get", Err: cloudfirestore.ErrNotPathable}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(p, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Get", Path: path, Err: err}
+	}
+	d, err := i.read(path)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Get", Path: path, Err: err}
+	}
+	return dataTo(ctx, "Get", path, d, data)
+}
+
+func (i *innerTran) GetAll(ctx context.Context, data []cloudfirestore.Pathable) ([]error, error) {
+	errs := make([]error, len(data))
+	for idx, p := range data {
+		path := p.Path(ctx)
+		if err := cloudfirestore.ValidatePath(p, path); err != nil {
+			return nil, &cloudfirestore.OpError{Op: "GetAll", Path: path, Err: err}
+		}
+		d, err := i.read(path)
+		if err != nil {
+			return nil, &cloudfirestore.OpError{Op: "GetAll", Path: path, Err: err}
+		}
+		errs[idx] = dataTo(ctx, "GetAll", path, d, p)
+	}
+	return errs, nil
+}
+
+func (i *innerTran) Query(ctx context.Context, q cloudfirestore.Query, f func(context.Context, *cloudfirestore.Document) error) (int, error) {
+	if len(i.writes) > 0 {
+		return 0, &cloudfirestore.OpError{Op: "Query", Err: errReadAfterWrite}
+	}
+	results, _, err := i.store.evaluate(q)
+	if err != nil {
+		return 0, &cloudfirestore.OpError{Op: "Query", Err: err}
+	}
+	now := time.Now()
+	qr := queryRead{q: q, paths: make([]string, len(results))}
+	docs := make([]*cloudfirestore.Document, len(results))
+	for idx, r := range results {
+		if version, ok := i.reads[r.path]; ok && version != r.doc.version {
+			return 0, &cloudfirestore.OpError{Op: "Query", Path: r.path, Err: cloudfirestore.ErrContention}
+		}
+		i.reads[r.path] = r.doc.version
+		i.remember(r.path, r.doc)
+		qr.paths[idx] = r.path
+		docs[idx] = newDocument(r.path, r.doc, now)
+	}
+	i.queries = append(i.queries, qr)
+	for num, doc := range docs {
+		if err := f(ctx, doc); err != nil {
+			return num, err
+		}
+	}
+	return len(docs), nil
+}
+
+func (i *innerTran) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	if err := cloudfirestore.ValidateUpdates(data, updates); err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	w, err := updateWrite(path, updates)
+	if err != nil {
+		return &cloudfirestore.OpError{Op: "Update", Path: path, Err: err}
+	}
+	return i.writeVersioned(w, data)
+}
+
+func (i *innerTran) Delete(ctx context.Context, data any) error {
+	p, ok := data.(cloudfirestore.Pathable)
+	if !ok {
+		return &cloudfirestore.OpError{Op: "Delete", Err: cloudfirestore.ErrNotPathable}
+	}
+	if err := cloudfirestore.BeforeDelete(ctx, data); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: p.Path(ctx), Err: err}
+	}
+	path := p.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Delete", Path: path, Err: err}
+	}
+	return i.writeVersioned(deleteWrite(path), data)
+}
+
+// writeVersioned checks versioned data against the document read in the
+// transaction, like the Firestore backend, or else when committing.
+func (i *innerTran) writeVersioned(w write, data any) error {
+	d, ok := i.snapshots[w.path]
+	if !ok {
+		return i.write(versioned(w, data))
+	}
+	if err := checkVersion(w.op, data, d); err != nil {
+		return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: err}
+	}
+	return i.write(tracked(w, data))
+}
+
+func (i *innerTran) write(w write) error {
+	if i.readOnly {
+		return &cloudfirestore.OpError{Op: w.op, Path: w.path, Err: errReadOnly}
+	}
+	i.writes = append(i.writes, w)
+	return nil
+}
+
+// read loads the document and records its version, so the commit fails with
+// ErrContention if another writer changed it in the meantime.
+func (i *innerTran) read(path string) (*document, error) {
+	if len(i.writes) > 0 {
+		return nil, errReadAfterWrite
+	}
+	d := i.store.get(path)
+	if version, ok := i.reads[path]; ok {
+		if (d == nil && version != 0) || (d != nil && d.version != version) {
+			return nil, cloudfirestore.ErrContention
+		}
+		return d, nil
+	}
+	if d == nil {
+		i.reads[path] = 0
+	} else {
+		i.reads[path] = d.version
+	}
+	i.remember(path, d)
+	return d, nil
+}
+
+func (i *innerTran) remember(path string, d *document) {
+	if i.snapshots == nil {
+		i.snapshots = map[string]*document{}
+	}
+	i.snapshots[path] = d
+}
@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+func TestAfterCommitRetried(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Set(ctx, &book{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var ran []int
+	attempts := 0
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		attempts++
+		attempt := attempts
+		tx.AfterCommit(func(context.Context) { ran = append(ran, attempt) })
+		if len(ran) > 0 {
+			t.Error("hook ran before the commit")
+		}
+		b := &book{ID: "a"}
+		if err := tx.Get(ctx, b); err != nil {
+			return err
+		}
+		if attempt == 1 {
+			// Makes this attempt conflict.
+			if err := c.Set(ctx, &book{ID: "a", Year: 1}); err != nil {
+				return err
+			}
+		}
+		b.Year++
+		return tx.Set(ctx, b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts = %d, want 2", attempts)
+	}
+	if len(ran) != 1 || ran[0] != 2 {
+		t.Errorf("hooks ran for attempts %v, want only [2]", ran)
+	}
+}
+
+func TestAfterCommitNotRunOnError(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	errAbort := errors.New("abort")
+
+	ran := 0
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		tx.AfterCommit(func(context.Context) { ran++ })
+		if err := tx.Set(ctx, &book{ID: "a"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("RunTransaction = %v, want errAbort", err)
+	}
+	if ran != 0 {
+		t.Errorf("hook ran %d times, want 0", ran)
+	}
+	if err := c.Get(ctx, &book{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+
+	// A commit that fails also discards the hooks.
+	if err := c.Create(ctx, &book{ID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	err = c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		tx.AfterCommit(func(context.Context) { ran++ })
+		return tx.Create(ctx, &book{ID: "b"})
+	})
+	if !errors.Is(err, cloudfirestore.ErrAlreadyExists) {
+		t.Fatalf("RunTransaction = %v, want ErrAlreadyExists", err)
+	}
+	if ran != 0 {
+		t.Errorf("hook ran %d times, want 0", ran)
+	}
+}
+
+func TestAfterCommitOrderAndPanic(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+
+	var ran []string
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		tx.AfterCommit(func(context.Context) { ran = append(ran, "first") })
+		tx.AfterCommit(func(context.Context) { panic("hook failed") })
+		tx.AfterCommit(func(ctx context.Context) {
+			// The document is committed by the time hooks run.
+			if err := c.Get(ctx, &book{ID: "a"}); err != nil {
+				t.Errorf("Get in hook = %v", err)
+			}
+			ran = append(ran, "last")
+		})
+		return tx.Set(ctx, &book{ID: "a"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 2 || ran[0] != "first" || ran[1] != "last" {
+		t.Errorf("hooks ran %v, want [first last]", ran)
+	}
+}
@@ -0,0 +1,76 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+// versioned adds the optimistic concurrency check of cloudfirestore.Versioned
+// data to w, and records the new version once committed.
+func versioned(w write, data any) write {
+	if _, ok := cloudfirestore.VersionOf(data); !ok {
+		return w
+	}
+	apply := w.apply
+	w.apply = func(current *document) (map[string]any, error) {
+		if err := checkVersion(w.op, data, current); err != nil {
+			return nil, err
+		}
+		return apply(current)
+	}
+	return tracked(w, data)
+}
+
+// checkVersion compares the version of data with the current document.
+func checkVersion(op string, data any, current *document) error {
+	v, ok := cloudfirestore.VersionOf(data)
+	switch {
+	case !ok || op == "Create":
+	case !v.IsZero():
+		if current == nil || !current.updateTime.Equal(v) {
+			return cloudfirestore.ErrConflict
+		}
+	case op == "Set":
+		if current != nil {
+			return cloudfirestore.ErrConflict
+		}
+	}
+	return nil
+}
+
+// tracked records the new version of versioned data once w is committed.
+func tracked(w write, data any) write {
+	if _, ok := cloudfirestore.VersionOf(data); !ok {
+		return w
+	}
+	w.committed = func(updateTime time.Time) {
+		if w.op == "Delete" {
+			updateTime = time.Time{}
+		}
+		cloudfirestore.SetVersion(data, updateTime)
+	}
+	return w
+}
@@ -0,0 +1,148 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type article struct {
+	ID      string `firestore:"-"`
+	Title   string
+	Version time.Time `firestore:"-" cloudfirestore:"version"`
+}
+
+func (a *article) Path(context.Context) string {
+	return "articles/" + a.ID
+}
+
+func TestVersionStale(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Create(ctx, &article{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	stale := &article{ID: "a"}
+	if err := c.Get(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if stale.Version.IsZero() {
+		t.Fatal("Get did not set the version")
+	}
+	fresh := *stale
+	fresh.Title = "fresh"
+	if err := c.Set(ctx, &fresh); err != nil {
+		t.Fatal(err)
+	}
+	if !fresh.Version.After(stale.Version) {
+		t.Errorf("version after Set = %v, want after %v", fresh.Version, stale.Version)
+	}
+
+	stale.Title = "stale"
+	if err := c.Set(ctx, stale); !errors.Is(err, cloudfirestore.ErrConflict) {
+		t.Errorf("stale Set = %v, want ErrConflict", err)
+	}
+	if err := c.Update(ctx, stale, cloudfirestore.FieldUpdate{Path: "Title", Value: "stale"}); !errors.Is(err, cloudfirestore.ErrConflict) {
+		t.Errorf("stale Update = %v, want ErrConflict", err)
+	}
+	if err := c.Delete(ctx, stale); !errors.Is(err, cloudfirestore.ErrConflict) {
+		t.Errorf("stale Delete = %v, want ErrConflict", err)
+	}
+	got := &article{ID: "a"}
+	if err := c.Get(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "fresh" || !got.Version.Equal(fresh.Version) {
+		t.Errorf("stored %+v, want the fresh write", got)
+	}
+
+	// A zero version means the document must not exist yet.
+	if err := c.Set(ctx, &article{ID: "a", Title: "new"}); !errors.Is(err, cloudfirestore.ErrConflict) {
+		t.Errorf("Set with zero version = %v, want ErrConflict", err)
+	}
+	created := &article{ID: "b", Title: "new"}
+	if err := c.Set(ctx, created); err != nil {
+		t.Errorf("Set of a new document = %v", err)
+	}
+	if created.Version.IsZero() {
+		t.Error("Set did not set the version")
+	}
+
+	if err := c.Delete(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Version.IsZero() {
+		t.Errorf("version after Delete = %v, want zero", got.Version)
+	}
+}
+
+func TestVersionTransaction(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	a := &article{ID: "a"}
+	if err := c.Create(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	before := a.Version
+
+	var inTx *article
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		inTx = &article{ID: "a"}
+		if err := tx.Get(ctx, inTx); err != nil {
+			return err
+		}
+		inTx.Title = "first"
+		if err := tx.Set(ctx, inTx); err != nil {
+			return err
+		}
+		// A second write to the same document in the transaction.
+		return tx.Update(ctx, inTx, cloudfirestore.FieldUpdate{Path: "Title", Value: "second"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inTx.Version.After(before) {
+		t.Errorf("version after commit = %v, want after %v", inTx.Version, before)
+	}
+	got := &article{ID: "a"}
+	if err := c.Get(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "second" || !got.Version.Equal(inTx.Version) {
+		t.Errorf("stored %+v, want the second write at version %v", got, inTx.Version)
+	}
+
+	// Stale data fails without a read in the transaction too.
+	err = c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		return tx.Set(ctx, &article{ID: "a", Version: before})
+	})
+	if !errors.Is(err, cloudfirestore.ErrConflict) {
+		t.Errorf("stale Set in transaction = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+func (i *inner) Watch(ctx context.Context, data cloudfirestore.Pathable, f func(context.Context, cloudfirestore.Change) error) error {
+	path := data.Path(ctx)
+	if err := cloudfirestore.ValidatePath(data, path); err != nil {
+		return &cloudfirestore.OpError{Op: "Watch", Path: path, Err: err}
+	}
+	var last *cloudfirestore.Document
+	var version int64
+	for {
+		d, changed := i.store.getWatch(path)
+		var change cloudfirestore.Change
+		switch {
+		case d != nil && last == nil:
+			change = cloudfirestore.Change{Kind: cloudfirestore.ChangeAdded, Document: newDocument(path, d, time.Now())}
+		case d != nil && d.version != version:
+			change = cloudfirestore.Change{Kind: cloudfirestore.ChangeModified, Document: newDocument(path, d, time.Now())}
+		case d == nil && last != nil:
+			change = cloudfirestore.Change{Kind: cloudfirestore.ChangeRemoved, Document: last}
+		}
+		if change.Kind != 0 {
+			if d != nil {
+				last, version = change.Document, d.version
+			} else {
+				last, version = nil, 0
+			}
+			if err := f(ctx, change); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func (i *inner) WatchQuery(ctx context.Context, q cloudfirestore.Query, f func(context.Context, cloudfirestore.Change) error) error {
+	type entry struct {
+		doc     *cloudfirestore.Document
+		version int64
+	}
+	known := map[string]entry{}
+	for {
+		results, changed, err := i.store.evaluate(q)
+		if err != nil {
+			return &cloudfirestore.OpError{Op: "WatchQuery", Err: err}
+		}
+
+		now := time.Now()
+		var changes []cloudfirestore.Change
+		seen := map[string]bool{}
+		for _, r := range results {
+			seen[r.path] = true
+			prev, ok := known[r.path]
+			if ok && prev.version == r.doc.version {
+				continue
+			}
+			kind := cloudfirestore.ChangeAdded
+			if ok {
+				kind = cloudfirestore.ChangeModified
+			}
+			doc := newDocument(r.path, r.doc, now)
+			known[r.path] = entry{doc: doc, version: r.doc.version}
+			changes = append(changes, cloudfirestore.Change{Kind: kind, Document: doc})
+		}
+		for path, e := range known {
+			if !seen[path] {
+				delete(known, path)
+				changes = append(changes, cloudfirestore.Change{Kind: cloudfirestore.ChangeRemoved, Document: e.doc})
+			}
+		}
+
+		for _, c := range changes {
+			if err := f(ctx, c); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
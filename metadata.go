@@ -0,0 +1,154 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata describes the stored document data was loaded from.
+type Metadata struct {
+	ID string
+	// Path is the document path such as "users/1/orders/2".
+	Path string
+	// ParentIDs are the IDs of the ancestor documents from the root, such as
+	// ["1"] for "users/1/orders/2".
+	ParentIDs  []string
+	CreateTime time.Time
+	UpdateTime time.Time
+	ReadTime   time.Time
+}
+
+// MetadataReceiver is implemented by data receiving the metadata of the
+// document it is loaded from.
+//
+// Instead, a struct can tag fields with `cloudfirestore:"..."`, usually
+// together with `firestore:"-"`: "id", "path" and "parent" (the nearest
+// parent ID) on strings, "parents" on []string, and "createTime",
+// "updateTime" and "readTime" on time.Time.
+type MetadataReceiver interface {
+	SetMetadata(Metadata)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fieldTags caches the fields tagged with `cloudfirestore:"..."` of each
+// struct type, by tag.
+var fieldTags sync.Map
+
+func taggedFields(t reflect.Type) map[string]reflect.StructField {
+	if v, ok := fieldTags.Load(t); ok {
+		return v.(map[string]reflect.StructField)
+	}
+	fields := map[string]reflect.StructField{}
+	for _, f := range reflect.VisibleFields(t) {
+		if tag := f.Tag.Get("cloudfirestore"); tag != "" && f.IsExported() {
+			if _, ok := fields[tag]; !ok {
+				fields[tag] = f
+			}
+		}
+	}
+	v, _ := fieldTags.LoadOrStore(t, fields)
+	return v.(map[string]reflect.StructField)
+}
+
+// SetMetadata passes md to a MetadataReceiver or sets the tagged fields of
+// data, and fills the fields of its path template, for backends decoding
+// documents.
+func SetMetadata(data any, md Metadata) {
+	if t, _ := templateOf(data); t != nil {
+		// Documents of another shape, e.g. from a collection group, keep
+		// their fields.
+		_ = t.Parse(md.Path, data)
+	}
+	if r, ok := data.(MetadataReceiver); ok {
+		r.SetMetadata(md)
+		return
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rv = rv.Elem()
+	fields := taggedFields(rv.Type())
+	if len(fields) == 0 {
+		return
+	}
+	var parent string
+	if len(md.ParentIDs) > 0 {
+		parent = md.ParentIDs[len(md.ParentIDs)-1]
+	}
+	for tag, v := range map[string]any{
+		"id":         md.ID,
+		"path":       md.Path,
+		"parent":     parent,
+		"parents":    md.ParentIDs,
+		"createTime": md.CreateTime,
+		"updateTime": md.UpdateTime,
+		"readTime":   md.ReadTime,
+	} {
+		f, ok := fields[tag]
+		if !ok {
+			continue
+		}
+		value := reflect.ValueOf(v)
+		if f.Type != value.Type() {
+			continue
+		}
+		if fv, ok := allocField(rv, f.Index); ok {
+			fv.Set(value)
+		}
+	}
+}
+
+// allocField returns the field of rv at index like FieldByIndex, allocating
+// nil embedded struct pointers on the way. It reports false when one of them
+// cannot be set.
+func allocField(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for idx, x := range index {
+		if idx > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !rv.CanSet() {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
+
+// parentIDs returns the IDs of the ancestor documents of path.
+func parentIDs(path string) []string {
+	segs := strings.Split(path, "/")
+	var ids []string
+	for idx := 1; idx < len(segs)-1; idx += 2 {
+		ids = append(ids, segs[idx])
+	}
+	return ids
+}
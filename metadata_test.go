@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type DocMeta struct {
+	ID      string    `firestore:"-" cloudfirestore:"id"`
+	Created time.Time `firestore:"-" cloudfirestore:"createTime"`
+	Version time.Time `firestore:"-" cloudfirestore:"version"`
+}
+
+type docMeta struct {
+	Updated time.Time `firestore:"-" cloudfirestore:"updateTime"`
+}
+
+type metaDoc struct {
+	*DocMeta
+	*docMeta
+	Name string
+}
+
+func (d *metaDoc) Path(context.Context) string {
+	return "docs/a"
+}
+
+func TestVersionNilEmbedded(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	data := &metaDoc{Name: "x"}
+	if v, ok := cloudfirestore.VersionOf(data); !ok || !v.IsZero() {
+		t.Errorf("VersionOf = %v, %t, want zero, true", v, ok)
+	}
+	if err := c.Set(ctx, data); err != nil {
+		t.Fatal(err)
+	}
+	if data.DocMeta == nil || data.Version.IsZero() {
+		t.Errorf("DocMeta = %+v, want the version set", data.DocMeta)
+	}
+}
+
+func TestSetMetadataNilEmbedded(t *testing.T) {
+	doc, err := memory.NewDocument("docs/a", map[string]any{"Name": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.CreateTime = time.Unix(100, 0)
+	var data metaDoc
+	if err := doc.DataTo(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Name != "x" {
+		t.Errorf("Name = %q, want %q", data.Name, "x")
+	}
+	if data.DocMeta == nil || data.ID != "a" || !data.Created.Equal(doc.CreateTime) {
+		t.Errorf("DocMeta = %+v, want ID a created at %v", data.DocMeta, doc.CreateTime)
+	}
+	if !data.Version.Equal(doc.UpdateTime) {
+		t.Errorf("Version = %v, want %v", data.Version, doc.UpdateTime)
+	}
+	if data.docMeta != nil {
+		t.Errorf("docMeta = %+v, want nil as it cannot be set", data.docMeta)
+	}
+}
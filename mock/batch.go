@@ -0,0 +1,80 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mock
+
+import (
+	"context"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/stretchr/testify/mock"
+)
+
+type innerBatch struct {
+	mock  *mock.Mock
+	batch cloudfirestore.Batch
+}
+
+func (i *innerBatch) Create(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Create", data, err)
+	}
+	return i.batch.Create(ctx, data)
+}
+
+func (i *innerBatch) Set(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Set", data, err)
+	}
+	return i.batch.Set(ctx, data)
+}
+
+func (i *innerBatch) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	args := i.mock.Called(ctx, data, updates)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Update", data, err)
+	}
+	return i.batch.Update(ctx, data, updates...)
+}
+
+func (i *innerBatch) Delete(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Delete", data, err)
+	}
+	return i.batch.Delete(ctx, data)
+}
+
+func (i *innerBatch) Commit(ctx context.Context) error {
+	args := i.mock.Called(ctx)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Commit", nil, err)
+	}
+	return i.batch.Commit(ctx)
+}
@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mock
+
+import (
+	"context"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/stretchr/testify/mock"
+)
+
+type innerBulk struct {
+	mock *mock.Mock
+	bulk cloudfirestore.BulkWriter
+}
+
+func (i *innerBulk) Create(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Create", data, err)
+	}
+	return i.bulk.Create(ctx, data)
+}
+
+func (i *innerBulk) Set(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Set", data, err)
+	}
+	return i.bulk.Set(ctx, data)
+}
+
+func (i *innerBulk) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	args := i.mock.Called(ctx, data, updates)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Update", data, err)
+	}
+	return i.bulk.Update(ctx, data, updates...)
+}
+
+func (i *innerBulk) Delete(ctx context.Context, data any) error {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Delete", data, err)
+	}
+	return i.bulk.Delete(ctx, data)
+}
+
+func (i *innerBulk) Flush(ctx context.Context) (cloudfirestore.BulkSummary, error) {
+	args := i.mock.Called(ctx)
+	err := args.Error(1)
+	if err != nil {
+		return cloudfirestore.BulkSummary{}, opError(ctx, "Flush", nil, err)
+	}
+	return i.bulk.Flush(ctx)
+}
+
+func (i *innerBulk) End(ctx context.Context) (cloudfirestore.BulkSummary, error) {
+	args := i.mock.Called(ctx)
+	err := args.Error(1)
+	if err != nil {
+		return cloudfirestore.BulkSummary{}, opError(ctx, "End", nil, err)
+	}
+	return i.bulk.End(ctx)
+}
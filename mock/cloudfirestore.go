@@ -25,7 +25,6 @@ package mock
 import (
 	"context"
 
-	"cloud.google.com/go/firestore"
 	"github.com/Eigen438/cloudfirestore"
 	"github.com/stretchr/testify/mock"
 )
@@ -45,7 +44,7 @@ func New(m *mock.Mock, firestoreService cloudfirestore.CloudFirestore) cloudfire
 func (i *inner) Create(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	if err := args.Error(0); err != nil {
-		return err
+		return opError(ctx, "Create", data, err)
 	}
 	return i.client.Create(ctx, data)
 }
@@ -53,7 +52,7 @@ func (i *inner) Create(ctx context.Context, data any) error {
 func (i *inner) Delete(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	if err := args.Error(0); err != nil {
-		return err
+		return opError(ctx, "Delete", data, err)
 	}
 	return i.client.Delete(ctx, data)
 }
@@ -61,23 +60,57 @@ func (i *inner) Delete(ctx context.Context, data any) error {
 func (i *inner) Get(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	if err := args.Error(0); err != nil {
-		return err
+		return opError(ctx, "Get", data, err)
 	}
 	return i.client.Get(ctx, data)
 }
 
+func (i *inner) GetAll(ctx context.Context, data []cloudfirestore.Pathable) ([]error, error) {
+	args := i.mock.Called(ctx, data)
+	if err := args.Error(1); err != nil {
+		return nil, opError(ctx, "GetAll", nil, err)
+	}
+	return i.client.GetAll(ctx, data)
+}
+
+func (i *inner) Exists(ctx context.Context, data cloudfirestore.Pathable) (bool, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return args.Bool(0), opError(ctx, "Exists", data, err)
+	}
+	return i.client.Exists(ctx, data)
+}
+
+func (i *inner) ExistsAll(ctx context.Context, data []cloudfirestore.Pathable) ([]bool, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return nil, opError(ctx, "ExistsAll", nil, err)
+	}
+	return i.client.ExistsAll(ctx, data)
+}
+
 func (i *inner) Set(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	if err := args.Error(0); err != nil {
-		return err
+		return opError(ctx, "Set", data, err)
 	}
 	return i.client.Set(ctx, data)
 }
 
-func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, cloudfirestore.Transaction) error) error {
+func (i *inner) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	args := i.mock.Called(ctx, data, updates)
+	if err := args.Error(0); err != nil {
+		return opError(ctx, "Update", data, err)
+	}
+	return i.client.Update(ctx, data, updates...)
+}
+
+func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, cloudfirestore.Transaction) error, opts ...cloudfirestore.TransactionOption) error {
 	args := i.mock.Called(ctx, f)
 	if err := args.Error(0); err != nil {
-		return err
+		return opError(ctx, "RunTransaction", nil, err)
 	}
 	return i.client.RunTransaction(ctx, func(_ctx context.Context, tran cloudfirestore.Transaction) error {
 		tx := &innerTran{
@@ -85,42 +118,191 @@ func (i *inner) RunTransaction(ctx context.Context, f func(context.Context, clou
 			tran: tran,
 		}
 		return f(_ctx, tx)
-	})
+	}, opts...)
 }
 
-func (i *inner) Collection(collectionName string) firestore.Query {
+func (i *inner) Batch() cloudfirestore.Batch {
+	i.mock.Called()
+	return &innerBatch{
+		mock:  i.mock,
+		batch: i.client.Batch(),
+	}
+}
+
+func (i *inner) Bulk(ctx context.Context) cloudfirestore.BulkWriter {
+	i.mock.Called(ctx)
+	return &innerBulk{
+		mock: i.mock,
+		bulk: i.client.Bulk(ctx),
+	}
+}
+
+func (i *inner) Collection(collectionName string) cloudfirestore.Query {
 	i.mock.Called(collectionName)
 	return i.client.Collection(collectionName)
 }
 
-func (i *inner) CollectionGroup(collectionName string) firestore.Query {
+func (i *inner) CollectionGroup(collectionName string) cloudfirestore.Query {
 	i.mock.Called(collectionName)
 	return i.client.CollectionGroup(collectionName)
 }
 
-func (i *inner) Sequence(ctx context.Context, q firestore.Query, f func(ctx context.Context, snapshot *firestore.DocumentSnapshot) error) (int, error) {
+func (i *inner) Sequence(ctx context.Context, q cloudfirestore.Query, f func(ctx context.Context, doc *cloudfirestore.Document) error) (int, error) {
 	args := i.mock.Called(ctx, q, f)
 	err := args.Error(1)
 	if err != nil {
-		return args.Int(0), err
+		return args.Int(0), opError(ctx, "Sequence", nil, err)
+	}
+	if docs, ok := cannedDocuments(args); ok {
+		return sequence(ctx, docs, f)
 	}
 	return i.client.Sequence(ctx, q, f)
 }
 
-func (i *inner) Run(ctx context.Context, q firestore.Query, concurrency int, f func(ctx context.Context, snapshot *firestore.DocumentSnapshot) error) (int, error) {
+func (i *inner) Run(ctx context.Context, q cloudfirestore.Query, concurrency int, f func(ctx context.Context, doc *cloudfirestore.Document) error, opts ...cloudfirestore.RunOption) (int, error) {
 	args := i.mock.Called(ctx, q, concurrency, f)
 	err := args.Error(1)
 	if err != nil {
-		return args.Int(0), err
+		return args.Int(0), opError(ctx, "Run", nil, err)
+	}
+	if docs, ok := cannedDocuments(args); ok {
+		return cloudfirestore.RunDocuments(ctx, concurrency, cloudfirestore.SliceDocuments(docs), f, opts...)
 	}
-	return i.client.Run(ctx, q, concurrency, f)
+	return i.client.Run(ctx, q, concurrency, f, opts...)
 }
 
-func (i *inner) DeleteWithQuery(ctx context.Context, q firestore.Query, concurrency int) (int, error) {
+func (i *inner) DeleteWithQuery(ctx context.Context, q cloudfirestore.Query, concurrency int, opts ...cloudfirestore.DeleteOption) (int, error) {
 	args := i.mock.Called(ctx, q, concurrency)
 	err := args.Error(1)
 	if err != nil {
-		return args.Int(0), err
+		return args.Int(0), opError(ctx, "DeleteWithQuery", nil, err)
+	}
+	return i.client.DeleteWithQuery(ctx, q, concurrency, opts...)
+}
+
+func (i *inner) DeleteRecursive(ctx context.Context, data cloudfirestore.Pathable, opts ...cloudfirestore.DeleteOption) (int, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return args.Int(0), opError(ctx, "DeleteRecursive", data, err)
+	}
+	return i.client.DeleteRecursive(ctx, data, opts...)
+}
+
+// opError wraps an injected error the same way the real implementation does,
+// so callers can classify mock failures with the cloudfirestore helpers.
+func opError(ctx context.Context, op string, data any, err error) error {
+	path := ""
+	if p, ok := data.(cloudfirestore.Pathable); ok {
+		path = p.Path(ctx)
+	}
+	return cloudfirestore.NewOpError(op, path, err)
+}
+
+// Count returns the first Return value as a canned result when it is an
+// int64, e.g. m.On("Count", ...).Return(int64(3), nil), and asks the client
+// otherwise. Sum, Average and Aggregate do the same with float64 and
+// cloudfirestore.AggregationResult.
+func (i *inner) Count(ctx context.Context, q cloudfirestore.Query) (int64, error) {
+	args := i.mock.Called(ctx, q)
+	if err := args.Error(1); err != nil {
+		return 0, opError(ctx, "Count", nil, err)
+	}
+	if v, ok := args.Get(0).(int64); ok {
+		return v, nil
+	}
+	return i.client.Count(ctx, q)
+}
+
+func (i *inner) Sum(ctx context.Context, q cloudfirestore.Query, field string) (float64, error) {
+	args := i.mock.Called(ctx, q, field)
+	if err := args.Error(1); err != nil {
+		return 0, opError(ctx, "Sum", nil, err)
+	}
+	if v, ok := args.Get(0).(float64); ok {
+		return v, nil
+	}
+	return i.client.Sum(ctx, q, field)
+}
+
+func (i *inner) Average(ctx context.Context, q cloudfirestore.Query, field string) (float64, error) {
+	args := i.mock.Called(ctx, q, field)
+	if err := args.Error(1); err != nil {
+		return 0, opError(ctx, "Average", nil, err)
+	}
+	if v, ok := args.Get(0).(float64); ok {
+		return v, nil
+	}
+	return i.client.Average(ctx, q, field)
+}
+
+func (i *inner) Aggregate(ctx context.Context, q cloudfirestore.Query, aggregations ...cloudfirestore.Aggregation) (cloudfirestore.AggregationResult, error) {
+	args := i.mock.Called(ctx, q, aggregations)
+	if err := args.Error(1); err != nil {
+		return nil, opError(ctx, "Aggregate", nil, err)
+	}
+	if v, ok := args.Get(0).(cloudfirestore.AggregationResult); ok {
+		return v, nil
+	}
+	return i.client.Aggregate(ctx, q, aggregations...)
+}
+
+func (i *inner) Watch(ctx context.Context, data cloudfirestore.Pathable, f func(context.Context, cloudfirestore.Change) error) error {
+	args := i.mock.Called(ctx, data, f)
+	if err := args.Error(0); err != nil {
+		return opError(ctx, "Watch", data, err)
+	}
+	if changes, ok := cannedChanges(args); ok {
+		return deliver(ctx, changes, f)
+	}
+	return i.client.Watch(ctx, data, f)
+}
+
+func (i *inner) WatchQuery(ctx context.Context, q cloudfirestore.Query, f func(context.Context, cloudfirestore.Change) error) error {
+	args := i.mock.Called(ctx, q, f)
+	if err := args.Error(0); err != nil {
+		return opError(ctx, "WatchQuery", nil, err)
+	}
+	if changes, ok := cannedChanges(args); ok {
+		return deliver(ctx, changes, f)
+	}
+	return i.client.WatchQuery(ctx, q, f)
+}
+
+// cannedDocuments returns canned query results given as the third Return value,
+// e.g. m.On("Sequence", ...).Return(0, nil, docs).
+func cannedDocuments(args mock.Arguments) ([]*cloudfirestore.Document, bool) {
+	if len(args) < 3 {
+		return nil, false
+	}
+	docs, ok := args.Get(2).([]*cloudfirestore.Document)
+	return docs, ok
+}
+
+func sequence(ctx context.Context, docs []*cloudfirestore.Document, f func(context.Context, *cloudfirestore.Document) error) (int, error) {
+	for num, doc := range docs {
+		if err := f(ctx, doc); err != nil {
+			return num, err
+		}
+	}
+	return len(docs), nil
+}
+
+// cannedChanges returns canned watch changes given as the second Return value,
+// e.g. m.On("Watch", ...).Return(nil, changes).
+func cannedChanges(args mock.Arguments) ([]cloudfirestore.Change, bool) {
+	if len(args) < 2 {
+		return nil, false
+	}
+	changes, ok := args.Get(1).([]cloudfirestore.Change)
+	return changes, ok
+}
+
+func deliver(ctx context.Context, changes []cloudfirestore.Change, f func(context.Context, cloudfirestore.Change) error) error {
+	for _, c := range changes {
+		if err := f(ctx, c); err != nil {
+			return err
+		}
 	}
-	return i.client.DeleteWithQuery(ctx, q, concurrency)
+	return nil
 }
@@ -0,0 +1,92 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+	cfmock "github.com/Eigen438/cloudfirestore/mock"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type book struct {
+	ID string `firestore:"-"`
+}
+
+func (b *book) Path(context.Context) string {
+	return "books/" + b.ID
+}
+
+func TestInjectedErrorsAreClassified(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		method string
+		code   codes.Code
+		want   error
+	}{
+		{"Get", codes.NotFound, cloudfirestore.ErrNotFound},
+		{"Create", codes.AlreadyExists, cloudfirestore.ErrAlreadyExists},
+		{"Set", codes.Aborted, cloudfirestore.ErrContention},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			m := &mock.Mock{}
+			m.On(tt.method, mock.Anything, mock.Anything).Return(status.Error(tt.code, "injected"))
+			c := cfmock.New(m, memory.New())
+
+			var err error
+			switch tt.method {
+			case "Get":
+				err = c.Get(ctx, &book{ID: "a"})
+			case "Create":
+				err = c.Create(ctx, &book{ID: "a"})
+			case "Set":
+				err = c.Set(ctx, &book{ID: "a"})
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("%s = %v, want %v", tt.method, err, tt.want)
+			}
+			var oe *cloudfirestore.OpError
+			if !errors.As(err, &oe) || oe.Op != tt.method || oe.Path != "books/a" {
+				t.Errorf("%s = %#v, want an OpError for books/a", tt.method, err)
+			}
+			if status.Code(err) != tt.code {
+				t.Errorf("status code = %v, want %v", status.Code(err), tt.code)
+			}
+		})
+	}
+	m := &mock.Mock{}
+	m.On("Get", mock.Anything, mock.Anything).Return(status.Error(codes.NotFound, "injected"))
+	if err := cfmock.New(m, memory.New()).Get(ctx, &book{ID: "a"}); !cloudfirestore.IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false", err)
+	}
+	if !cloudfirestore.IsRetryable(cloudfirestore.NewOpError("Set", "books/a", status.Error(codes.Aborted, "injected"))) {
+		t.Error("aborted error is not retryable")
+	}
+}
@@ -38,7 +38,7 @@ func (i *innerTran) Create(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	err := args.Error(0)
 	if err != nil {
-		return err
+		return opError(ctx, "Create", data, err)
 	}
 	return i.tran.Create(ctx, data)
 }
@@ -47,7 +47,7 @@ func (i *innerTran) Set(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	err := args.Error(0)
 	if err != nil {
-		return err
+		return opError(ctx, "Set", data, err)
 	}
 	return i.tran.Set(ctx, data)
 }
@@ -56,16 +56,69 @@ func (i *innerTran) Get(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	err := args.Error(0)
 	if err != nil {
-		return err
+		return opError(ctx, "Get", data, err)
 	}
 	return i.tran.Get(ctx, data)
 }
 
+func (i *innerTran) Exists(ctx context.Context, data cloudfirestore.Pathable) (bool, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return args.Bool(0), opError(ctx, "Exists", data, err)
+	}
+	return i.tran.Exists(ctx, data)
+}
+
+func (i *innerTran) ExistsAll(ctx context.Context, data []cloudfirestore.Pathable) ([]bool, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return nil, opError(ctx, "ExistsAll", nil, err)
+	}
+	return i.tran.ExistsAll(ctx, data)
+}
+
+func (i *innerTran) Update(ctx context.Context, data cloudfirestore.Pathable, updates ...cloudfirestore.FieldUpdate) error {
+	args := i.mock.Called(ctx, data, updates)
+	err := args.Error(0)
+	if err != nil {
+		return opError(ctx, "Update", data, err)
+	}
+	return i.tran.Update(ctx, data, updates...)
+}
+
+func (i *innerTran) GetAll(ctx context.Context, data []cloudfirestore.Pathable) ([]error, error) {
+	args := i.mock.Called(ctx, data)
+	err := args.Error(1)
+	if err != nil {
+		return nil, opError(ctx, "GetAll", nil, err)
+	}
+	return i.tran.GetAll(ctx, data)
+}
+
+func (i *innerTran) Query(ctx context.Context, q cloudfirestore.Query, f func(context.Context, *cloudfirestore.Document) error) (int, error) {
+	args := i.mock.Called(ctx, q, f)
+	err := args.Error(1)
+	if err != nil {
+		return args.Int(0), opError(ctx, "Query", nil, err)
+	}
+	if docs, ok := cannedDocuments(args); ok {
+		return sequence(ctx, docs, f)
+	}
+	return i.tran.Query(ctx, q, f)
+}
+
+func (i *innerTran) AfterCommit(f func(context.Context)) {
+	i.mock.Called(f)
+	i.tran.AfterCommit(f)
+}
+
 func (i *innerTran) Delete(ctx context.Context, data any) error {
 	args := i.mock.Called(ctx, data)
 	err := args.Error(0)
 	if err != nil {
-		return err
+		return opError(ctx, "Delete", data, err)
 	}
 	return i.tran.Delete(ctx, data)
 }
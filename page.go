@@ -0,0 +1,297 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrInvalidPageToken is reported for page tokens that were altered, signed
+// with another key or issued for another query.
+var ErrInvalidPageToken = errors.New("cloudfirestore: invalid page token")
+
+// pageTokenKey holds the key signing page tokens.
+var pageTokenKey = func() *atomic.Pointer[[]byte] {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	var p atomic.Pointer[[]byte]
+	p.Store(&key)
+	return &p
+}()
+
+// SetPageTokenKey sets the key that signs page tokens. By default a random
+// key is used, so tokens are only valid within the process; share a key to
+// accept tokens across instances. Tokens signed with the previous key are
+// rejected afterwards.
+func SetPageTokenKey(key []byte) {
+	key = append([]byte(nil), key...)
+	pageTokenKey.Store(&key)
+}
+
+// PageResult is one page of query results.
+type PageResult struct {
+	Documents []*Document
+	// NextToken fetches the following page, or is empty on the last page.
+	NextToken string
+	// PrevToken fetches the preceding page, or is empty on the first page.
+	PrevToken string
+}
+
+type pageToken struct {
+	Backward bool         `json:"b,omitempty"`
+	Query    string       `json:"q"`
+	Values   []tokenValue `json:"v"`
+}
+
+// tokenValue keeps the Go type of an order value through JSON.
+type tokenValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// Paginate returns the page of q that the token points at, or the first
+// page for an empty token. q must not set its own cursors or limit.
+func Paginate(ctx context.Context, c CloudFirestore, q Query, pageSize int, token string) (*PageResult, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("cloudfirestore: page size must be positive, got %d", pageSize)
+	}
+	spec := q.Spec()
+	dir := Asc
+	if len(spec.Orders) > 0 {
+		dir = spec.Orders[len(spec.Orders)-1].Direction
+	}
+	if len(spec.Orders) == 0 || spec.Orders[len(spec.Orders)-1].Path != DocumentID {
+		q = q.OrderBy(DocumentID, dir)
+	}
+	orders := q.Spec().Orders
+	fingerprint := queryFingerprint(q.Spec())
+
+	var pt *pageToken
+	if token != "" {
+		var err error
+		if pt, err = decodePageToken(token, fingerprint); err != nil {
+			return nil, err
+		}
+		if len(pt.Values) != len(orders) {
+			return nil, ErrInvalidPageToken
+		}
+	}
+
+	pq := q.Limit(pageSize + 1)
+	if pt != nil {
+		values, err := fromTokenValues(pt.Values)
+		if err != nil {
+			return nil, err
+		}
+		if pt.Backward {
+			// Firestore cannot stream limitToLast queries, so walk back
+			// in reverse order and flip the page afterwards.
+			pq = reverseOrders(q).StartAfter(values...).Limit(pageSize + 1)
+		} else {
+			pq = q.StartAfter(values...).Limit(pageSize + 1)
+		}
+	}
+
+	var docs []*Document
+	if _, err := c.Sequence(ctx, pq, func(_ context.Context, doc *Document) error {
+		docs = append(docs, doc)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	more := len(docs) > pageSize
+	backward := pt != nil && pt.Backward
+	if more {
+		docs = docs[:pageSize]
+	}
+	if backward {
+		slices.Reverse(docs)
+	}
+
+	ret := &PageResult{Documents: docs}
+	if len(docs) == 0 {
+		return ret, nil
+	}
+	var err error
+	if backward || more {
+		if ret.NextToken, err = encodePageToken(false, fingerprint, orders, docs[len(docs)-1]); err != nil {
+			return nil, err
+		}
+	}
+	if (backward && more) || (!backward && pt != nil) {
+		if ret.PrevToken, err = encodePageToken(true, fingerprint, orders, docs[0]); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// reverseOrders returns q with the direction of every order flipped.
+func reverseOrders(q Query) Query {
+	orders := make([]Order, len(q.spec.Orders))
+	for idx, o := range q.spec.Orders {
+		o.Direction = Desc
+		if q.spec.Orders[idx].Direction == Desc {
+			o.Direction = Asc
+		}
+		orders[idx] = o
+	}
+	q.spec.Orders = orders
+	return q
+}
+
+func pageItems[T any](page *PageResult) ([]*T, error) {
+	items := make([]*T, len(page.Documents))
+	for idx, doc := range page.Documents {
+		items[idx] = new(T)
+		if err := doc.DataTo(items[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// queryFingerprint ties a token to the query it was issued for.
+func queryFingerprint(spec QuerySpec) string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%t|%v|%v", spec.Collection, spec.AllDescendants, spec.Filters, spec.Orders))
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+func encodePageToken(backward bool, fingerprint string, orders []Order, doc *Document) (string, error) {
+	data := doc.Data()
+	values := make([]tokenValue, len(orders))
+	for idx, o := range orders {
+		var v any = doc.Path
+		if o.Path != DocumentID {
+			v = lookupField(data, o.Path)
+		}
+		tv, err := toTokenValue(v)
+		if err != nil {
+			return "", err
+		}
+		values[idx] = tv
+	}
+	payload, err := json.Marshal(pageToken{Backward: backward, Query: fingerprint, Values: values})
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, *pageTokenKey.Load())
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func decodePageToken(token, fingerprint string) (*pageToken, error) {
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(p)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	mac := hmac.New(sha256.New, *pageTokenKey.Load())
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, ErrInvalidPageToken
+	}
+	pt := &pageToken{}
+	if err := json.Unmarshal(payload, pt); err != nil || pt.Query != fingerprint {
+		return nil, ErrInvalidPageToken
+	}
+	return pt, nil
+}
+
+func lookupField(data map[string]any, path string) any {
+	var v any = data
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func toTokenValue(v any) (tokenValue, error) {
+	switch t := v.(type) {
+	case nil:
+		return tokenValue{Type: "n"}, nil
+	case bool:
+		return tokenValue{Type: "b", Value: strconv.FormatBool(t)}, nil
+	case int64:
+		return tokenValue{Type: "i", Value: strconv.FormatInt(t, 10)}, nil
+	case float64:
+		return tokenValue{Type: "f", Value: strconv.FormatFloat(t, 'g', -1, 64)}, nil
+	case string:
+		return tokenValue{Type: "s", Value: t}, nil
+	case time.Time:
+		return tokenValue{Type: "t", Value: t.Format(time.RFC3339Nano)}, nil
+	}
+	return tokenValue{}, fmt.Errorf("cloudfirestore: can't page on order value of type %T", v)
+}
+
+func fromTokenValues(tvs []tokenValue) ([]any, error) {
+	ret := make([]any, len(tvs))
+	for idx, tv := range tvs {
+		var err error
+		switch tv.Type {
+		case "n":
+		case "b":
+			ret[idx], err = strconv.ParseBool(tv.Value)
+		case "i":
+			ret[idx], err = strconv.ParseInt(tv.Value, 10, 64)
+		case "f":
+			ret[idx], err = strconv.ParseFloat(tv.Value, 64)
+		case "s":
+			ret[idx] = tv.Value
+		case "t":
+			ret[idx], err = time.Parse(time.RFC3339Nano, tv.Value)
+		default:
+			err = ErrInvalidPageToken
+		}
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+	}
+	return ret, nil
+}
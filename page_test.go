@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type pageItem struct {
+	ID   string `firestore:"-" cloudfirestore:"id"`
+	Rank int
+}
+
+func (p *pageItem) Path(context.Context) string {
+	return "pageItems/" + p.ID
+}
+
+// streamOnly rejects what the Firestore SDK cannot stream.
+type streamOnly struct {
+	cloudfirestore.CloudFirestore
+}
+
+func (s streamOnly) Sequence(ctx context.Context, q cloudfirestore.Query, f func(context.Context, *cloudfirestore.Document) error) (int, error) {
+	if q.Spec().LimitToLast {
+		return 0, errors.New("queries that include limitToLast constraints cannot be streamed")
+	}
+	return s.CloudFirestore.Sequence(ctx, q, f)
+}
+
+func TestPaginateBackward(t *testing.T) {
+	testPaginate(t, streamOnly{memory.New()})
+}
+
+func TestPaginateBackwardEmulator(t *testing.T) {
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set")
+	}
+	c, err := cloudfirestore.New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPaginate(t, c)
+}
+
+func testPaginate(t *testing.T, c cloudfirestore.CloudFirestore) {
+	ctx := context.Background()
+	var all []string
+	for idx := range 8 {
+		item := &pageItem{ID: fmt.Sprintf("item%d", idx), Rank: idx % 3}
+		if err := c.Set(ctx, item); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { c.Delete(context.Background(), item) })
+		all = append(all, item.ID)
+	}
+
+	for _, dir := range []cloudfirestore.Direction{cloudfirestore.Asc, cloudfirestore.Desc} {
+		q := c.Collection("pageItems").OrderBy("Rank", dir)
+		var want []string
+		if _, err := c.Sequence(ctx, q.OrderBy(cloudfirestore.DocumentID, dir), func(_ context.Context, d *cloudfirestore.Document) error {
+			want = append(want, d.ID)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(want) != len(all) {
+			t.Fatalf("query returned %d documents, want %d", len(want), len(all))
+		}
+
+		// Walk forward to the last page, then back to the first.
+		var pages [][]string
+		var prev string
+		token := ""
+		for {
+			page, err := cloudfirestore.Paginate(ctx, c, q, 3, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pages = append(pages, ids(page))
+			prev = page.PrevToken
+			if page.NextToken == "" {
+				break
+			}
+			token = page.NextToken
+		}
+		if got := slices.Concat(pages...); !slices.Equal(got, want) {
+			t.Fatalf("forward pages %v, want %v", pages, want)
+		}
+		for idx := len(pages) - 2; idx >= 0; idx-- {
+			page, err := cloudfirestore.Paginate(ctx, c, q, 3, prev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(page); !slices.Equal(got, pages[idx]) {
+				t.Errorf("backward page %d = %v, want %v", idx, got, pages[idx])
+			}
+			if page.NextToken == "" {
+				t.Errorf("backward page %d has no next token", idx)
+			}
+			if (page.PrevToken == "") != (idx == 0) {
+				t.Errorf("backward page %d prev token = %q", idx, page.PrevToken)
+			}
+			prev = page.PrevToken
+		}
+	}
+}
+
+func ids(page *cloudfirestore.PageResult) []string {
+	ret := make([]string, len(page.Documents))
+	for idx, d := range page.Documents {
+		ret[idx] = d.ID
+	}
+	return ret
+}
+
+func TestSetPageTokenKeyConcurrent(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	for idx := range 3 {
+		if err := c.Set(ctx, &pageItem{ID: fmt.Sprintf("item%d", idx)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for idx := range 100 {
+			cloudfirestore.SetPageTokenKey([]byte(fmt.Sprintf("key%d", idx)))
+		}
+	}()
+	for range 100 {
+		if _, err := cloudfirestore.Paginate(ctx, c, c.Collection("pageItems"), 1, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+
+	cloudfirestore.SetPageTokenKey([]byte("key"))
+	page, err := cloudfirestore.Paginate(ctx, c, c.Collection("pageItems"), 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloudfirestore.SetPageTokenKey([]byte("other"))
+	if _, err := cloudfirestore.Paginate(ctx, c, c.Collection("pageItems"), 1, page.NextToken); !errors.Is(err, cloudfirestore.ErrInvalidPageToken) {
+		t.Errorf("Paginate with a token of the old key = %v, want ErrInvalidPageToken", err)
+	}
+}
@@ -0,0 +1,112 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidatePath checks that path, returned by data, is a document path: an
+// even number of valid segments. The error names the type of data, and for
+// data with a path template the reason BuildPath failed.
+func ValidatePath(data any, path string) error {
+	err := checkPath(path)
+	if err == nil {
+		return nil
+	}
+	if t, terr := templateOf(data); t != nil || terr != nil {
+		if _, berr := BuildPath(data); berr != nil {
+			err = berr
+		}
+	}
+	return fmt.Errorf("%w from %T: %v", ErrInvalidPath, data, err)
+}
+
+func checkPath(path string) error {
+	if path == "" {
+		return errors.New("empty path")
+	}
+	segs := strings.Split(path, "/")
+	for idx, seg := range segs {
+		if err := checkSegment(seg); err != nil {
+			return fmt.Errorf("segment %d: %v", idx+1, err)
+		}
+	}
+	if len(segs)%2 != 0 {
+		return fmt.Errorf("%d segments, a document needs an even number", len(segs))
+	}
+	return nil
+}
+
+// DocPath builds a document path segment by segment, checking each one:
+//
+//	func (o Order) Path(context.Context) string {
+//		return cloudfirestore.Doc("users", o.UserID).Doc("orders", o.ID).String()
+//	}
+//
+// A malformed segment is kept, so the operation using the path fails with
+// the reason; Err reports it earlier.
+type DocPath struct {
+	segs []string
+	err  error
+}
+
+// Doc starts a path at the document id of a root collection.
+func Doc(collection, id string) DocPath {
+	return DocPath{}.Doc(collection, id)
+}
+
+// Doc appends the document id of a subcollection.
+func (p DocPath) Doc(collection, id string) DocPath {
+	segs := make([]string, len(p.segs), len(p.segs)+2)
+	copy(segs, p.segs)
+	ret := DocPath{segs: append(segs, collection, id), err: p.err}
+	if ret.err == nil {
+		for idx, seg := range []string{collection, id} {
+			if err := checkSegment(seg); err != nil {
+				ret.err = fmt.Errorf("%w: segment %d: %v", ErrInvalidPath, len(p.segs)+idx+1, err)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// Collection returns the path of the collection the document belongs to.
+func (p DocPath) Collection() string {
+	if len(p.segs) == 0 {
+		return ""
+	}
+	return strings.Join(p.segs[:len(p.segs)-1], "/")
+}
+
+// Err reports the first malformed segment.
+func (p DocPath) Err() error {
+	return p.err
+}
+
+func (p DocPath) String() string {
+	return strings.Join(p.segs, "/")
+}
@@ -0,0 +1,92 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type rawPath string
+
+func (p rawPath) Path(context.Context) string { return string(p) }
+
+func TestValidatePath(t *testing.T) {
+	for path, want := range map[string]string{
+		"users/1":           "",
+		"users/1/orders/2":  "",
+		"users":             "segments",
+		"users/1/orders":    "segments",
+		"":                  "empty path",
+		"users//orders/2":   "empty segment",
+		"users/1/":          "empty segment",
+		"users/../orders/2": "not allowed",
+		"users/__id__":      "reserved",
+	} {
+		err := cloudfirestore.ValidatePath(rawPath(path), path)
+		if want == "" {
+			if err != nil {
+				t.Errorf("ValidatePath(%q) = %v, want nil", path, err)
+			}
+			continue
+		}
+		if !errors.Is(err, cloudfirestore.ErrInvalidPath) || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidatePath(%q) = %v, want ErrInvalidPath about %q", path, err, want)
+		}
+	}
+}
+
+func TestDocPath(t *testing.T) {
+	p := cloudfirestore.Doc("users", "1").Doc("orders", "2")
+	if p.Err() != nil || p.String() != "users/1/orders/2" || p.Collection() != "users/1/orders" {
+		t.Errorf("Doc = %q in %q, %v", p.String(), p.Collection(), p.Err())
+	}
+
+	for _, tc := range []struct {
+		p    cloudfirestore.DocPath
+		want string
+	}{
+		{cloudfirestore.Doc("users", "a/b"), "segment 2: segment \"a/b\" contains a slash"},
+		{cloudfirestore.Doc("users", ""), "segment 2: empty segment"},
+		{cloudfirestore.Doc("", "1"), "segment 1: empty segment"},
+		// The first malformed segment is reported.
+		{cloudfirestore.Doc("users", "1").Doc("orders", "").Doc("items", "a/b"), "segment 4: empty segment"},
+	} {
+		err := tc.p.Err()
+		if !errors.Is(err, cloudfirestore.ErrInvalidPath) || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Doc(%q).Err() = %v, want ErrInvalidPath about %q", tc.p.String(), err, tc.want)
+		}
+	}
+
+	// The malformed path fails the operation using it.
+	ctx := context.Background()
+	bad := rawPath(cloudfirestore.Doc("users", "a/b").String())
+	if err := memory.New().Set(ctx, bad); !errors.Is(err, cloudfirestore.ErrInvalidPath) {
+		t.Errorf("Set = %v, want ErrInvalidPath", err)
+	}
+}
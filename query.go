@@ -0,0 +1,269 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/firestore"
+)
+
+// DocumentID is the special field path that refers to the document name.
+// Values compared with it are document paths, or plain IDs for queries
+// on a single collection.
+const DocumentID = "__name__"
+
+// Direction is the sort direction of an OrderBy clause.
+type Direction int
+
+const (
+	Asc Direction = iota + 1
+	Desc
+)
+
+// Filter is a single Where clause.
+type Filter struct {
+	Path  string
+	Op    string
+	Value any
+}
+
+// Order is a single OrderBy clause.
+type Order struct {
+	Path      string
+	Direction Direction
+}
+
+// Cursor is a query start or end position given as OrderBy field values.
+type Cursor struct {
+	Values    []any
+	Inclusive bool
+}
+
+// QuerySpec is the backend-neutral description of a Query.
+type QuerySpec struct {
+	// Collection is the collection path, or the collection ID when
+	// AllDescendants is set.
+	Collection     string
+	AllDescendants bool
+	Filters        []Filter
+	Orders         []Order
+	Offset         int
+	Limit          int
+	LimitToLast    bool
+	Start          *Cursor
+	End            *Cursor
+}
+
+// Query is an immutable query builder. Each method returns a new Query.
+type Query struct {
+	spec QuerySpec
+}
+
+// CollectionQuery returns a query over the collection at path.
+func CollectionQuery(path string) Query {
+	return Query{spec: QuerySpec{Collection: path}}
+}
+
+// CollectionGroupQuery returns a query over all collections with the ID.
+func CollectionGroupQuery(collectionID string) Query {
+	return Query{spec: QuerySpec{Collection: collectionID, AllDescendants: true}}
+}
+
+// Spec returns the description of the query for backends to evaluate.
+func (q Query) Spec() QuerySpec {
+	return q.spec
+}
+
+// Where adds a filter. Op is one of "==", "!=", "<", "<=", ">", ">=",
+// "array-contains", "array-contains-any", "in" or "not-in".
+func (q Query) Where(path, op string, value any) Query {
+	if path == DocumentID {
+		value = q.documentValue(value)
+	}
+	q.spec.Filters = append(slices.Clip(q.spec.Filters), Filter{Path: path, Op: op, Value: value})
+	return q
+}
+
+// OrderBy adds a sort order.
+func (q Query) OrderBy(path string, dir Direction) Query {
+	q.spec.Orders = append(slices.Clip(q.spec.Orders), Order{Path: path, Direction: dir})
+	return q
+}
+
+// Offset skips the first n results.
+func (q Query) Offset(n int) Query {
+	q.spec.Offset = n
+	return q
+}
+
+// Limit returns at most the first n results.
+func (q Query) Limit(n int) Query {
+	q.spec.Limit = n
+	q.spec.LimitToLast = false
+	return q
+}
+
+// LimitToLast returns at most the last n results.
+func (q Query) LimitToLast(n int) Query {
+	q.spec.Limit = n
+	q.spec.LimitToLast = true
+	return q
+}
+
+// StartAt starts the results at the given OrderBy values, inclusive.
+func (q Query) StartAt(values ...any) Query {
+	q.spec.Start = q.cursor(values, true)
+	return q
+}
+
+// StartAfter starts the results after the given OrderBy values.
+func (q Query) StartAfter(values ...any) Query {
+	q.spec.Start = q.cursor(values, false)
+	return q
+}
+
+// EndAt ends the results at the given OrderBy values, inclusive.
+func (q Query) EndAt(values ...any) Query {
+	q.spec.End = q.cursor(values, true)
+	return q
+}
+
+// EndBefore ends the results before the given OrderBy values.
+func (q Query) EndBefore(values ...any) Query {
+	q.spec.End = q.cursor(values, false)
+	return q
+}
+
+func (q Query) cursor(values []any, inclusive bool) *Cursor {
+	c := &Cursor{Values: slices.Clone(values), Inclusive: inclusive}
+	for idx, o := range q.spec.Orders {
+		if o.Path == DocumentID && idx < len(c.Values) {
+			c.Values[idx] = q.documentValue(c.Values[idx])
+		}
+	}
+	return c
+}
+
+// documentValue expands plain document IDs to paths for collection queries.
+func (q Query) documentValue(v any) any {
+	switch t := v.(type) {
+	case string:
+		if !q.spec.AllDescendants && !strings.Contains(t, "/") {
+			return q.spec.Collection + "/" + t
+		}
+	case []string:
+		ret := make([]any, len(t))
+		for idx, s := range t {
+			ret[idx] = q.documentValue(s)
+		}
+		return ret
+	case []any:
+		ret := make([]any, len(t))
+		for idx, s := range t {
+			ret[idx] = q.documentValue(s)
+		}
+		return ret
+	}
+	return v
+}
+
+func toFirestoreQuery(client *firestore.Client, q Query) firestore.Query {
+	spec := q.Spec()
+	var fq firestore.Query
+	if spec.AllDescendants {
+		fq = client.CollectionGroup(spec.Collection).Query
+	} else {
+		fq = client.Collection(spec.Collection).Query
+	}
+	docValue := func(v any) any {
+		switch t := v.(type) {
+		case string:
+			return client.Doc(t)
+		case []any:
+			ret := make([]any, len(t))
+			for idx, s := range t {
+				if p, ok := s.(string); ok {
+					ret[idx] = client.Doc(p)
+					continue
+				}
+				ret[idx] = s
+			}
+			return ret
+		}
+		return v
+	}
+	for _, f := range spec.Filters {
+		if f.Path == DocumentID {
+			fq = fq.WherePath(firestore.FieldPath{DocumentID}, f.Op, docValue(f.Value))
+			continue
+		}
+		fq = fq.Where(f.Path, f.Op, f.Value)
+	}
+	for _, o := range spec.Orders {
+		dir := firestore.Asc
+		if o.Direction == Desc {
+			dir = firestore.Desc
+		}
+		if o.Path == DocumentID {
+			fq = fq.OrderByPath(firestore.FieldPath{DocumentID}, dir)
+			continue
+		}
+		fq = fq.OrderBy(o.Path, dir)
+	}
+	cursorValues := func(c *Cursor) []any {
+		values := slices.Clone(c.Values)
+		for idx, o := range spec.Orders {
+			if o.Path == DocumentID && idx < len(values) {
+				values[idx] = docValue(values[idx])
+			}
+		}
+		return values
+	}
+	if spec.Start != nil {
+		if spec.Start.Inclusive {
+			fq = fq.StartAt(cursorValues(spec.Start)...)
+		} else {
+			fq = fq.StartAfter(cursorValues(spec.Start)...)
+		}
+	}
+	if spec.End != nil {
+		if spec.End.Inclusive {
+			fq = fq.EndAt(cursorValues(spec.End)...)
+		} else {
+			fq = fq.EndBefore(cursorValues(spec.End)...)
+		}
+	}
+	if spec.Offset > 0 {
+		fq = fq.Offset(spec.Offset)
+	}
+	if spec.Limit > 0 {
+		if spec.LimitToLast {
+			fq = fq.LimitToLast(spec.Limit)
+		} else {
+			fq = fq.Limit(spec.Limit)
+		}
+	}
+	return fq
+}
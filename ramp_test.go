@@ -0,0 +1,62 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestBulkRamp(t *testing.T) {
+	ctx := context.Background()
+	r := newBulkRamp()
+	if err := r.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.limiter.Limit(); got != bulkStartRate {
+		t.Errorf("limit = %v, want %v", got, bulkStartRate)
+	}
+
+	for _, tt := range []struct {
+		elapsed time.Duration
+		want    rate.Limit
+	}{
+		{4 * time.Minute, 500},
+		{5 * time.Minute, 750},
+		{11 * time.Minute, 1125},
+	} {
+		r.start = time.Now().Add(-tt.elapsed)
+		if err := r.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.limiter.Limit(); got != tt.want {
+			t.Errorf("after %v limit = %v, want %v", tt.elapsed, got, tt.want)
+		}
+		if got := r.limiter.Burst(); got != int(tt.want) {
+			t.Errorf("after %v burst = %d, want %d", tt.elapsed, got, int(tt.want))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// errReadOnly is returned for writes in a ReadTime transaction.
+var errReadOnly = errors.New("cloudfirestore: write in read-only transaction")
+
+// readTimeTran reads through a client pinned to a read time. The SDK pins
+// the reads of a transaction to the transaction instead, and ignores the
+// read time of queries unless it is set on the client.
+type readTimeTran struct {
+	inner *inner
+	hooks []func(context.Context)
+}
+
+// runAt runs f with the reads of a separate client set to read at t.
+func (i *inner) runAt(ctx context.Context, t time.Time, f func(context.Context, Transaction) error) error {
+	ctx, span := tracer.Start(ctx, "Transaction/ReadTime")
+	defer span.End()
+
+	if i.newClient == nil {
+		return newOpError("RunTransaction", "", errors.New("cloudfirestore: read time needs a client from New or NewWithDatabase"))
+	}
+	client, err := i.newClient(ctx)
+	if err != nil {
+		return newOpError("RunTransaction", "", err)
+	}
+	defer client.Close()
+	client.WithReadOptions(firestore.ReadTime(t))
+
+	tran := &readTimeTran{
+		inner: &inner{client: client},
+	}
+	if err := f(ctx, tran); err != nil {
+		return newOpError("RunTransaction", "", err)
+	}
+	RunAfterCommit(ctx, tran.hooks)
+	return nil
+}
+
+func (r *readTimeTran) AfterCommit(f func(context.Context)) {
+	r.hooks = append(r.hooks, f)
+}
+
+func (r *readTimeTran) Get(ctx context.Context, data any) error {
+	return r.inner.Get(ctx, data)
+}
+
+func (r *readTimeTran) GetAll(ctx context.Context, data []Pathable) ([]error, error) {
+	return r.inner.GetAll(ctx, data)
+}
+
+func (r *readTimeTran) Exists(ctx context.Context, data Pathable) (bool, error) {
+	return r.inner.Exists(ctx, data)
+}
+
+func (r *readTimeTran) ExistsAll(ctx context.Context, data []Pathable) ([]bool, error) {
+	return r.inner.ExistsAll(ctx, data)
+}
+
+func (r *readTimeTran) Query(ctx context.Context, q Query, f func(context.Context, *Document) error) (int, error) {
+	return r.inner.Sequence(ctx, q, f)
+}
+
+func (r *readTimeTran) Create(ctx context.Context, data any) error {
+	return newOpError("Create", pathOf(ctx, data), errReadOnly)
+}
+
+func (r *readTimeTran) Set(ctx context.Context, data any) error {
+	return newOpError("Set", pathOf(ctx, data), errReadOnly)
+}
+
+func (r *readTimeTran) Update(ctx context.Context, data Pathable, _ ...FieldUpdate) error {
+	return newOpError("Update", pathOf(ctx, data), errReadOnly)
+}
+
+func (r *readTimeTran) Delete(ctx context.Context, data any) error {
+	return newOpError("Delete", pathOf(ctx, data), errReadOnly)
+}
+
+func pathOf(ctx context.Context, data any) string {
+	if p, ok := data.(Pathable); ok {
+		return p.Path(ctx)
+	}
+	return ""
+}
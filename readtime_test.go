@@ -0,0 +1,82 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+func TestReadTimeTransaction(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeFirestore(2)
+	c := newFakeClient(t, f)
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	var found int
+	committed := false
+	err := c.RunTransaction(ctx, func(ctx context.Context, tx cloudfirestore.Transaction) error {
+		tx.AfterCommit(func(context.Context) { committed = true })
+		if err := tx.Get(ctx, &bulkItem{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+			t.Errorf("Get = %v, want ErrNotFound", err)
+		}
+		n, err := tx.Query(ctx, c.Collection("items"), func(context.Context, *cloudfirestore.Document) error { return nil })
+		if err != nil {
+			return err
+		}
+		found = n
+		if err := tx.Set(ctx, &bulkItem{ID: "a"}); err == nil {
+			t.Error("Set in a read time transaction succeeded")
+		}
+		return nil
+	}, cloudfirestore.ReadTime(at))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != 2 {
+		t.Errorf("Query found %d, want 2", found)
+	}
+	if !committed {
+		t.Error("AfterCommit hook did not run")
+	}
+	if len(f.readTimes) != 2 {
+		t.Fatalf("server saw %d reads, want 2", len(f.readTimes))
+	}
+	for idx, rt := range f.readTimes {
+		if !rt.Equal(at) {
+			t.Errorf("read %d at %v, want %v", idx, rt, at)
+		}
+	}
+
+	// Reads outside it are not affected.
+	if err := c.Get(ctx, &bulkItem{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Fatalf("Get = %v, want ErrNotFound", err)
+	}
+	if rt := f.readTimes[len(f.readTimes)-1]; !rt.IsZero() {
+		t.Errorf("Get read at %v, want current data", rt)
+	}
+}
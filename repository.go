@@ -0,0 +1,133 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Repository is a typed view of the documents of type T, where *T
+// implements Pathable.
+type Repository[T any, PT interface {
+	*T
+	Pathable
+}] struct {
+	client CloudFirestore
+}
+
+// NewRepository returns a Repository backed by client.
+// PT is inferred, as in NewRepository[Book](client).
+func NewRepository[T any, PT interface {
+	*T
+	Pathable
+}](client CloudFirestore) *Repository[T, PT] {
+	return &Repository[T, PT]{
+		client: client,
+	}
+}
+
+// Get reads the document addressed by key.
+func (r *Repository[T, PT]) Get(ctx context.Context, key T) (*T, error) {
+	data := key
+	if err := r.client.Get(ctx, PT(&data)); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Create creates the document.
+func (r *Repository[T, PT]) Create(ctx context.Context, data *T) error {
+	return r.client.Create(ctx, data)
+}
+
+// Set creates or overwrites the document.
+func (r *Repository[T, PT]) Set(ctx context.Context, data *T) error {
+	return r.client.Set(ctx, data)
+}
+
+// Update changes only the given fields of the document.
+func (r *Repository[T, PT]) Update(ctx context.Context, data *T, updates ...FieldUpdate) error {
+	return r.client.Update(ctx, PT(data), updates...)
+}
+
+// Delete deletes the document.
+func (r *Repository[T, PT]) Delete(ctx context.Context, data *T) error {
+	return r.client.Delete(ctx, data)
+}
+
+// List returns all results of the query.
+func (r *Repository[T, PT]) List(ctx context.Context, q Query) ([]*T, error) {
+	var ret []*T
+	_, err := r.Query(ctx, q, func(_ context.Context, data *T, _ *Document) error {
+		ret = append(ret, data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Query calls f with each result of the query in order.
+func (r *Repository[T, PT]) Query(ctx context.Context, q Query, f func(context.Context, *T, *Document) error) (int, error) {
+	return r.client.Sequence(ctx, q, func(ctx context.Context, doc *Document) error {
+		data := new(T)
+		if err := doc.DataTo(data); err != nil {
+			return err
+		}
+		return f(ctx, data, doc)
+	})
+}
+
+// Page returns the page of q that the token points at, see Paginate.
+func (r *Repository[T, PT]) Page(ctx context.Context, q Query, pageSize int, token string) ([]*T, *PageResult, error) {
+	page, err := Paginate(ctx, r.client, q, pageSize, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	items, err := pageItems[T](page)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, page, nil
+}
+
+var errStopStream = errors.New("cloudfirestore: stream stopped")
+
+// Stream returns an iterator over the results of the query. Iteration ends
+// after the first error.
+func (r *Repository[T, PT]) Stream(ctx context.Context, q Query) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		_, err := r.Query(ctx, q, func(_ context.Context, data *T, _ *Document) error {
+			if !yield(data, nil) {
+				return errStopStream
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopStream) {
+			yield(nil, err)
+		}
+	}
+}
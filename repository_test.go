@@ -0,0 +1,78 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type repoBook struct {
+	ID    string `firestore:"-" cloudfirestore:"id"`
+	Title string
+}
+
+func (b *repoBook) Path(context.Context) string {
+	return "repoBooks/" + b.ID
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	books := cloudfirestore.NewRepository[repoBook](memory.New())
+
+	if err := books.Create(ctx, &repoBook{ID: "a", Title: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := books.Create(ctx, &repoBook{ID: "a"}); !errors.Is(err, cloudfirestore.ErrAlreadyExists) {
+		t.Errorf("Create = %v, want ErrAlreadyExists", err)
+	}
+	got, err := books.Get(ctx, repoBook{ID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "A" {
+		t.Errorf("Title = %q, want %q", got.Title, "A")
+	}
+
+	if err := books.Update(ctx, &repoBook{ID: "a"}, cloudfirestore.FieldUpdate{Path: "Title", Value: "B"}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := books.List(ctx, cloudfirestore.CollectionQuery("repoBooks"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ID != "a" || list[0].Title != "B" {
+		t.Errorf("List = %+v, want a single updated book", list)
+	}
+
+	if err := books.Delete(ctx, &repoBook{ID: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := books.Get(ctx, repoBook{ID: "a"}); !errors.Is(err, cloudfirestore.ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+}
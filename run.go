@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/api/iterator"
+)
+
+// RunStats reports how many documents Run handed to the handler and how
+// many of those calls succeeded.
+type RunStats struct {
+	Processed int
+	Succeeded int
+}
+
+// RunOption configures Run.
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	continueOnError bool
+	stats           *RunStats
+}
+
+// ContinueOnError keeps Run going after a handler fails, instead of
+// canceling the remaining work.
+func ContinueOnError() RunOption {
+	return func(c *runConfig) {
+		c.continueOnError = true
+	}
+}
+
+// WithRunStats stores the counts of the finished Run in stats.
+func WithRunStats(stats *RunStats) RunOption {
+	return func(c *runConfig) {
+		c.stats = stats
+	}
+}
+
+// RunDocuments calls f for each document returned by next with at most
+// concurrency calls in flight, and is what Run is built on. next receives
+// the context that is canceled on the first failure and must return
+// iterator.Done after the last document. It returns the number of processed
+// documents and all handler errors joined.
+func RunDocuments(ctx context.Context, concurrency int, next func(context.Context) (*Document, error), f func(context.Context, *Document) error, opts ...RunOption) (int, error) {
+	cfg := &runConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		errs      []error
+		failed    bool
+		succeeded int
+		processed int
+	)
+	sem := make(chan struct{}, max(concurrency, 1))
+loop:
+	for ctx.Err() == nil {
+		d, err := next(ctx)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			mu.Lock()
+			if !failed || ctx.Err() == nil {
+				errs = append(errs, newOpError("Run", "", err))
+			}
+			mu.Unlock()
+			break
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		processed++
+		wg.Add(1)
+		go func(_ctx context.Context, _d *Document) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_ctx, span := tracer.Start(_ctx, "Run/Process:"+_d.ID)
+			defer span.End()
+			err := f(_ctx, _d)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				span.RecordError(err)
+				errs = append(errs, err)
+				failed = true
+				if !cfg.continueOnError {
+					cancel()
+				}
+				return
+			}
+			succeeded++
+		}(ctx, d)
+	}
+	wg.Wait()
+
+	if cfg.stats != nil {
+		cfg.stats.Processed = processed
+		cfg.stats.Succeeded = succeeded
+	}
+	if len(errs) == 0 && ctx.Err() != nil {
+		// Without a failure, only the caller can have canceled ctx.
+		errs = append(errs, newOpError("Run", "", ctx.Err()))
+	}
+	return processed, errors.Join(errs...)
+}
+
+// SliceDocuments returns a RunDocuments source that yields docs in order.
+func SliceDocuments(docs []*Document) func(context.Context) (*Document, error) {
+	return func(context.Context) (*Document, error) {
+		if len(docs) == 0 {
+			return nil, iterator.Done
+		}
+		d := docs[0]
+		docs = docs[1:]
+		return d, nil
+	}
+}
@@ -0,0 +1,177 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Eigen438/cloudfirestore"
+)
+
+var errHandler = errors.New("handler failed")
+
+func documents(n int) []*cloudfirestore.Document {
+	docs := make([]*cloudfirestore.Document, n)
+	for idx := range docs {
+		id := strconv.Itoa(idx)
+		docs[idx] = &cloudfirestore.Document{ID: id, Path: "items/" + id}
+	}
+	return docs
+}
+
+func TestRunDocumentsConcurrency(t *testing.T) {
+	const total, concurrency = 2000, 16
+	var inFlight, peak atomic.Int32
+	var stats cloudfirestore.RunStats
+	n, err := cloudfirestore.RunDocuments(context.Background(), concurrency, cloudfirestore.SliceDocuments(documents(total)), func(context.Context, *cloudfirestore.Document) error {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Microsecond)
+		return nil
+	}, cloudfirestore.WithRunStats(&stats))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != total || stats.Processed != total || stats.Succeeded != total {
+		t.Errorf("n = %d, stats = %+v, want %d", n, stats, total)
+	}
+	if p := peak.Load(); p > concurrency {
+		t.Errorf("peak in flight = %d, want <= %d", p, concurrency)
+	}
+}
+
+func TestRunDocumentsCancelOnFailure(t *testing.T) {
+	const total = 5000
+	var stats cloudfirestore.RunStats
+	var canceled atomic.Int32
+	n, err := cloudfirestore.RunDocuments(context.Background(), 8, cloudfirestore.SliceDocuments(documents(total)), func(ctx context.Context, d *cloudfirestore.Document) error {
+		if d.ID == "100" {
+			return errHandler
+		}
+		select {
+		case <-ctx.Done():
+			canceled.Add(1)
+			return ctx.Err()
+		case <-time.After(100 * time.Microsecond):
+			return nil
+		}
+	}, cloudfirestore.WithRunStats(&stats))
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("err = %v, want errHandler", err)
+	}
+	if n >= total {
+		t.Errorf("n = %d, want fewer than %d after cancel", n, total)
+	}
+	if stats.Processed != n {
+		t.Errorf("Processed = %d, want %d", stats.Processed, n)
+	}
+	failures := len(err.(interface{ Unwrap() []error }).Unwrap())
+	if want := 1 + int(canceled.Load()); failures != want {
+		t.Errorf("joined %d errors, want %d", failures, want)
+	}
+	if stats.Succeeded+failures != n {
+		t.Errorf("Succeeded = %d with %d failures, want %d processed", stats.Succeeded, failures, n)
+	}
+}
+
+func TestRunDocumentsContinueOnError(t *testing.T) {
+	const total = 3000
+	var stats cloudfirestore.RunStats
+	n, err := cloudfirestore.RunDocuments(context.Background(), 32, cloudfirestore.SliceDocuments(documents(total)), func(ctx context.Context, d *cloudfirestore.Document) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id, _ := strconv.Atoi(d.ID)
+		if id%10 == 0 {
+			return fmt.Errorf("item %d: %w", id, errHandler)
+		}
+		return nil
+	}, cloudfirestore.ContinueOnError(), cloudfirestore.WithRunStats(&stats))
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("err = %v, want errHandler", err)
+	}
+	if n != total || stats.Processed != total {
+		t.Errorf("n = %d, Processed = %d, want %d", n, stats.Processed, total)
+	}
+	if want := total - total/10; stats.Succeeded != want {
+		t.Errorf("Succeeded = %d, want %d", stats.Succeeded, want)
+	}
+	errs := err.(interface{ Unwrap() []error }).Unwrap()
+	if len(errs) != total/10 {
+		t.Errorf("joined %d errors, want %d", len(errs), total/10)
+	}
+	for _, e := range errs {
+		if !errors.Is(e, errHandler) {
+			t.Errorf("joined error %v, want errHandler", e)
+		}
+	}
+}
+
+func TestRunDocumentsSourceError(t *testing.T) {
+	errSource := errors.New("source failed")
+	docs := documents(50)
+	next := func(context.Context) (*cloudfirestore.Document, error) {
+		if len(docs) == 0 {
+			return nil, errSource
+		}
+		d := docs[0]
+		docs = docs[1:]
+		return d, nil
+	}
+	var stats cloudfirestore.RunStats
+	n, err := cloudfirestore.RunDocuments(context.Background(), 4, next, func(context.Context, *cloudfirestore.Document) error {
+		return nil
+	}, cloudfirestore.WithRunStats(&stats))
+	if !errors.Is(err, errSource) {
+		t.Fatalf("err = %v, want errSource", err)
+	}
+	if n != 50 || stats.Succeeded != 50 {
+		t.Errorf("n = %d, Succeeded = %d, want 50", n, stats.Succeeded)
+	}
+}
+
+func TestRunDocumentsCallerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	_, err := cloudfirestore.RunDocuments(ctx, 8, cloudfirestore.SliceDocuments(documents(10000)), func(ctx context.Context, _ *cloudfirestore.Document) error {
+		if calls.Add(1) == 100 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
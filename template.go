@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// PathTemplate builds document paths from struct fields, such as
+// "users/{UserID}/orders/{ID}" where each placeholder names a string field
+// and fills a whole segment.
+//
+// A struct declares its template on a blank field:
+//
+//	type Order struct {
+//		_      struct{} `cloudfirestore:"path=users/{UserID}/orders/{ID}"`
+//		UserID string   `firestore:"-"`
+//		ID     string   `firestore:"-"`
+//	}
+//
+//	func (o Order) Path(context.Context) string { return cloudfirestore.TemplatePath(o) }
+//
+// Documents loaded into such a struct get the placeholder fields parsed
+// back from their path.
+type PathTemplate struct {
+	raw string
+	// segments are literal segments, or field names for placeholders.
+	segments []string
+	fields   []bool
+}
+
+// NewPathTemplate parses a document path template.
+func NewPathTemplate(s string) (*PathTemplate, error) {
+	segs := strings.Split(s, "/")
+	if len(segs)%2 != 0 {
+		return nil, fmt.Errorf("cloudfirestore: path template %q has an odd number of segments", s)
+	}
+	t := &PathTemplate{
+		raw:      s,
+		segments: make([]string, len(segs)),
+		fields:   make([]bool, len(segs)),
+	}
+	for idx, seg := range segs {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name := seg[1 : len(seg)-1]
+			if name == "" || strings.ContainsAny(name, "{}") {
+				return nil, fmt.Errorf("cloudfirestore: path template %q has an invalid placeholder %q", s, seg)
+			}
+			t.segments[idx], t.fields[idx] = name, true
+			continue
+		}
+		if err := checkSegment(seg); err != nil {
+			return nil, fmt.Errorf("cloudfirestore: path template %q: %w", s, err)
+		}
+		t.segments[idx] = seg
+	}
+	return t, nil
+}
+
+func (t *PathTemplate) String() string {
+	return t.raw
+}
+
+// Build returns the path of data, a struct or a pointer to one.
+func (t *PathTemplate) Build(data any) (string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(data))
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("cloudfirestore: path template %q needs a struct, got %T", t.raw, data)
+	}
+	segs := make([]string, len(t.segments))
+	for idx, seg := range t.segments {
+		if !t.fields[idx] {
+			segs[idx] = seg
+			continue
+		}
+		sf, ok := stringField(rv.Type(), seg)
+		if !ok {
+			return "", fmt.Errorf("cloudfirestore: path template %q: %T has no string field %s", t.raw, data, seg)
+		}
+		f, err := rv.FieldByIndexErr(sf.Index)
+		if err != nil {
+			return "", fmt.Errorf("cloudfirestore: path template %q: field %s of %T: %w", t.raw, seg, data, err)
+		}
+		if err := checkSegment(f.String()); err != nil {
+			return "", fmt.Errorf("cloudfirestore: path template %q: field %s of %T: %w", t.raw, seg, data, err)
+		}
+		segs[idx] = f.String()
+	}
+	return strings.Join(segs, "/"), nil
+}
+
+// Parse sets the placeholder fields of data, a pointer to a struct, from
+// path.
+func (t *PathTemplate) Parse(path string, data any) error {
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cloudfirestore: path template %q needs a pointer to a struct, got %T", t.raw, data)
+	}
+	rv = rv.Elem()
+	segs := strings.Split(path, "/")
+	if len(segs) != len(t.segments) {
+		return fmt.Errorf("cloudfirestore: path %q does not match template %q", path, t.raw)
+	}
+	for idx, seg := range t.segments {
+		if !t.fields[idx] && segs[idx] != seg {
+			return fmt.Errorf("cloudfirestore: path %q does not match template %q", path, t.raw)
+		}
+	}
+	for idx, seg := range t.segments {
+		if !t.fields[idx] {
+			continue
+		}
+		sf, ok := stringField(rv.Type(), seg)
+		if !ok {
+			return fmt.Errorf("cloudfirestore: path template %q: %T has no string field %s", t.raw, data, seg)
+		}
+		f, ok := allocField(rv, sf.Index)
+		if !ok || !f.CanSet() {
+			return fmt.Errorf("cloudfirestore: path template %q: %T has no settable string field %s", t.raw, data, seg)
+		}
+		f.SetString(segs[idx])
+	}
+	return nil
+}
+
+// stringField looks up the string field name of the struct type t, which
+// may be promoted from an embedded struct.
+func stringField(t reflect.Type, name string) (reflect.StructField, bool) {
+	f, ok := t.FieldByName(name)
+	return f, ok && f.Type.Kind() == reflect.String
+}
+
+// checkSegment reports why s can't be a collection or document ID.
+func checkSegment(s string) error {
+	switch {
+	case s == "":
+		return errors.New("empty segment")
+	case s == "." || s == "..":
+		return fmt.Errorf("segment %q is not allowed", s)
+	case strings.Contains(s, "/"):
+		return fmt.Errorf("segment %q contains a slash", s)
+	case strings.HasPrefix(s, "__") && strings.HasSuffix(s, "__"):
+		return fmt.Errorf("segment %q is reserved", s)
+	case len(s) > 1500:
+		return errors.New("segment is longer than 1500 bytes")
+	}
+	return nil
+}
+
+// templates caches the template declared by each struct type, or nil.
+var templates sync.Map
+
+type templateEntry struct {
+	t   *PathTemplate
+	err error
+}
+
+func templateOf(data any) (*PathTemplate, error) {
+	t := reflect.TypeOf(data)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	if v, ok := templates.Load(t); ok {
+		e := v.(templateEntry)
+		return e.t, e.err
+	}
+	var e templateEntry
+	for idx := 0; idx < t.NumField(); idx++ {
+		tag := t.Field(idx).Tag.Get("cloudfirestore")
+		if s, ok := strings.CutPrefix(tag, "path="); ok {
+			e.t, e.err = NewPathTemplate(s)
+			break
+		}
+	}
+	templates.Store(t, e)
+	return e.t, e.err
+}
+
+// BuildPath returns the path of data from the template its struct declares.
+func BuildPath(data any) (string, error) {
+	t, err := templateOf(data)
+	if err != nil {
+		return "", err
+	}
+	if t == nil {
+		return "", fmt.Errorf("cloudfirestore: %T declares no path template", data)
+	}
+	return t.Build(data)
+}
+
+// TemplatePath is BuildPath for implementing Pathable. It returns an empty,
+// invalid path on error, and operations report the error of BuildPath.
+func TemplatePath(data any) string {
+	path, err := BuildPath(data)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// ParsePath sets the fields of data, a pointer to a struct, from path using
+// the template its struct declares.
+func ParsePath(path string, data any) error {
+	t, err := templateOf(data)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("cloudfirestore: %T declares no path template", data)
+	}
+	return t.Parse(path, data)
+}
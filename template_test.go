@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type templateOrder struct {
+	_      struct{} `cloudfirestore:"path=users/{UserID}/orders/{ID}"`
+	UserID string   `firestore:"-"`
+	ID     string   `firestore:"-"`
+	Total  int
+}
+
+func (o *templateOrder) Path(context.Context) string {
+	return cloudfirestore.TemplatePath(o)
+}
+
+func TestTemplatePathError(t *testing.T) {
+	ctx := context.Background()
+	c := memory.New()
+	if err := c.Set(ctx, &templateOrder{UserID: "u", ID: "o"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range []*templateOrder{
+		{UserID: "u"},
+		{UserID: "u", ID: "a/b"},
+	} {
+		err := c.Set(ctx, data)
+		if !errors.Is(err, cloudfirestore.ErrInvalidPath) {
+			t.Fatalf("Set(%+v) = %v, want ErrInvalidPath", data, err)
+		}
+		if !strings.Contains(err.Error(), "field ID") {
+			t.Errorf("Set(%+v) = %v, want the failing field named", data, err)
+		}
+	}
+}
+
+type OrderBase struct {
+	ID string `firestore:"-"`
+}
+
+type embeddedOrder struct {
+	_ struct{} `cloudfirestore:"path=users/{UserID}/orders/{ID}"`
+	*OrderBase
+	UserID string `firestore:"-"`
+	Total  int
+}
+
+func (o *embeddedOrder) Path(context.Context) string {
+	return cloudfirestore.TemplatePath(o)
+}
+
+func TestTemplateNilEmbedded(t *testing.T) {
+	ctx := context.Background()
+	if _, err := cloudfirestore.BuildPath(&embeddedOrder{UserID: "u"}); err == nil {
+		t.Error("BuildPath with a nil embedded ID = nil error, want an error")
+	}
+	c := memory.New()
+	if err := c.Set(ctx, &embeddedOrder{UserID: "u"}); !errors.Is(err, cloudfirestore.ErrInvalidPath) {
+		t.Errorf("Set = %v, want ErrInvalidPath", err)
+	}
+	if err := c.Set(ctx, &embeddedOrder{OrderBase: &OrderBase{ID: "o"}, UserID: "u", Total: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []*embeddedOrder
+	if _, err := c.Sequence(ctx, c.CollectionGroup("orders"), func(_ context.Context, doc *cloudfirestore.Document) error {
+		data := &embeddedOrder{}
+		if err := doc.DataTo(data); err != nil {
+			return err
+		}
+		got = append(got, data)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].OrderBase == nil || got[0].ID != "o" || got[0].UserID != "u" || got[0].Total != 3 {
+		t.Fatalf("Sequence = %+v, want the order parsed from its path", got)
+	}
+}
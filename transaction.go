@@ -24,49 +24,322 @@ package cloudfirestore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"cloud.google.com/go/firestore"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// TransactionOptions is the configuration built from TransactionOption
+// values.
+type TransactionOptions struct {
+	ReadOnly    bool
+	MaxAttempts int
+	// ReadTime, when not zero, makes reads return the documents as they
+	// were at that time, see ReadTime.
+	ReadTime time.Time
+	// Backoff returns the delay before the given retry, starting at 1. When
+	// nil the SDK's default backoff is used.
+	Backoff func(retry int) time.Duration
+}
+
+// TransactionOption configures RunTransaction.
+type TransactionOption func(*TransactionOptions)
+
+// NewTransactionOptions applies opts, for backends implementing
+// RunTransaction.
+func NewTransactionOptions(opts ...TransactionOption) *TransactionOptions {
+	o := &TransactionOptions{
+		MaxAttempts: firestore.DefaultTransactionMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// ReadOnly runs a transaction that cannot write and takes no locks.
+func ReadOnly() TransactionOption {
+	return func(o *TransactionOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// MaxAttempts sets how many times the transaction is attempted.
+func MaxAttempts(n int) TransactionOption {
+	return func(o *TransactionOptions) {
+		o.MaxAttempts = n
+	}
+}
+
+// ReadTime runs a read-only transaction reading the documents as they were
+// at t. The SDK truncates t to whole seconds, and Firestore accepts times
+// within the last hour, or whole minutes within the point-in-time recovery
+// window. The reads are not transactional but all see the same snapshot,
+// and writes fail.
+func ReadTime(t time.Time) TransactionOption {
+	return func(o *TransactionOptions) {
+		o.ReadOnly = true
+		o.ReadTime = t
+	}
+}
+
+// WithBackoff sets the delay before each retry of the transaction.
+func WithBackoff(f func(retry int) time.Duration) TransactionOption {
+	return func(o *TransactionOptions) {
+		o.Backoff = f
+	}
+}
+
+// RunAfterCommit calls the hooks registered with Transaction.AfterCommit, for
+// backends implementing RunTransaction. A panicking hook is recorded in the
+// span and does not stop the others.
+func RunAfterCommit(ctx context.Context, hooks []func(context.Context)) {
+	if len(hooks) == 0 {
+		return
+	}
+	ctx, span := tracer.Start(ctx, "Transaction/AfterCommit")
+	defer span.End()
+
+	for _, hook := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err := fmt.Errorf("cloudfirestore: after commit hook panicked: %v", r)
+					span.RecordError(err)
+					span.SetStatus(otelcodes.Error, err.Error())
+				}
+			}()
+			hook(ctx)
+		}()
+	}
+}
+
 type innerTran struct {
 	client *firestore.Client
 	tran   *firestore.Transaction
+	hooks  []func(context.Context)
+
+	// snapshots are the documents read, to check versioned writes.
+	snapshots map[string]*firestore.DocumentSnapshot
+	// versioned updates the version of written data once committed.
+	versioned []func(commitTime time.Time)
+	// preconditioned is set when a write carries an update time
+	// precondition.
+	preconditioned bool
+	// wrote is set after the first write, when the SDK stops allowing
+	// reads.
+	wrote bool
+}
+
+func (i *innerTran) AfterCommit(f func(context.Context)) {
+	i.hooks = append(i.hooks, f)
+}
+
+func (i *innerTran) remember(path string, s *firestore.DocumentSnapshot) {
+	if i.snapshots == nil {
+		i.snapshots = map[string]*firestore.DocumentSnapshot{}
+	}
+	i.snapshots[path] = s
+}
+
+// track updates the version of versioned data after the commit.
+func (i *innerTran) track(data any, deleted bool) {
+	if _, ok := VersionOf(data); !ok {
+		return
+	}
+	i.versioned = append(i.versioned, func(commitTime time.Time) {
+		if deleted {
+			commitTime = time.Time{}
+		}
+		SetVersion(data, commitTime)
+	})
+}
+
+// errSetAfterWrite is returned for a versioned Set of a document that was
+// not read before the first write, as it can no longer be read.
+var errSetAfterWrite = errors.New("cloudfirestore: versioned Set after a write requires a prior Get in the transaction")
+
+// precondition checks versioned data against the document read in the
+// transaction, or returns the precondition checked by the commit.
+func (i *innerTran) precondition(path string, data any) ([]firestore.Precondition, error) {
+	v, ok := VersionOf(data)
+	if !ok || v.IsZero() {
+		return nil, nil
+	}
+	if s, ok := i.snapshots[path]; ok {
+		return nil, checkVersion(data, s)
+	}
+	i.preconditioned = true
+	return []firestore.Precondition{firestore.LastUpdateTime(v)}, nil
+}
+
+// TypedQuery runs q in the transaction, decoding each document into a T.
+func TypedQuery[T any](ctx context.Context, t Transaction, q Query, f func(ctx context.Context, data *T, doc *Document) error) (int, error) {
+	return t.Query(ctx, q, func(ctx context.Context, doc *Document) error {
+		data := new(T)
+		if err := doc.DataTo(data); err != nil {
+			return err
+		}
+		return f(ctx, data, doc)
+	})
 }
 
 func (i *innerTran) Create(ctx context.Context, data any) error {
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		return i.tran.Create(i.client.Doc(p.Path(ctx)), data)
+		AllocateID(ctx, data)
+		if err := BeforeCreate(ctx, data); err != nil {
+			return newOpError("Create", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Create", path, err)
+		}
+		if err := Validate(ctx, data); err != nil {
+			return newOpError("Create", path, err)
+		}
+		if err := i.tran.Create(i.client.Doc(path), data); err != nil {
+			return newOpError("Create", path, err)
+		}
+		i.wrote = true
+		i.track(data, false)
+		return nil
 	}
-	return fmt.Errorf("not implement Pathable")
+	return newOpError("Create", "", ErrNotPathable)
 }
 
 func (i *innerTran) Set(ctx context.Context, data any) error {
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		return i.tran.Set(i.client.Doc(p.Path(ctx)), data)
+		if err := BeforeSet(ctx, data); err != nil {
+			return newOpError("Set", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Set", path, err)
+		}
+		if err := Validate(ctx, data); err != nil {
+			return newOpError("Set", path, err)
+		}
+		ref := i.client.Doc(path)
+		if _, ok := VersionOf(data); ok {
+			s, ok := i.snapshots[path]
+			if !ok {
+				if i.wrote {
+					return newOpError("Set", path, errSetAfterWrite)
+				}
+				var err error
+				s, err = i.tran.Get(ref)
+				if err != nil && status.Code(err) != codes.NotFound {
+					return newOpError("Set", path, err)
+				}
+				i.remember(path, s)
+			}
+			if err := checkVersion(data, s); err != nil {
+				return newOpError("Set", path, err)
+			}
+		}
+		if err := i.tran.Set(ref, data); err != nil {
+			return newOpError("Set", path, err)
+		}
+		i.wrote = true
+		i.track(data, false)
+		return nil
 	}
-	return fmt.Errorf("not implement Pathable")
+	return newOpError("Set", "", ErrNotPathable)
 }
 
 func (i *innerTran) Get(ctx context.Context, data any) error {
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		snapshot, err := i.tran.Get(i.client.Doc(p.Path(ctx)))
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Get", path, err)
+		}
+		snapshot, err := i.tran.Get(i.client.Doc(path))
 		if err != nil {
-			return err
+			if status.Code(err) == codes.NotFound {
+				i.remember(path, snapshot)
+			}
+			return newOpError("Get", path, err)
 		}
-		return snapshot.DataTo(data)
+		i.remember(path, snapshot)
+		return newOpError("Get", path, snapshotTo(ctx, snapshot, data))
+	}
+	return newOpError("Get", "", ErrNotPathable)
+}
+
+func (i *innerTran) Query(ctx context.Context, q Query, f func(ctx context.Context, doc *Document) error) (int, error) {
+	iter := i.tran.Documents(toFirestoreQuery(i.client, q))
+	defer iter.Stop()
+	return sequence(ctx, "Transaction/Query", iter, f)
+}
+
+func (i *innerTran) Update(ctx context.Context, data Pathable, updates ...FieldUpdate) error {
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return newOpError("Update", path, err)
+	}
+	if err := ValidateUpdates(data, updates); err != nil {
+		return newOpError("Update", path, err)
+	}
+	fu, err := toFirestoreUpdates(updates)
+	if err != nil {
+		return newOpError("Update", path, err)
+	}
+	pre, err := i.precondition(path, data)
+	if err != nil {
+		return newOpError("Update", path, err)
+	}
+	if err := i.tran.Update(i.client.Doc(path), fu, pre...); err != nil {
+		return newOpError("Update", path, err)
+	}
+	i.wrote = true
+	i.track(data, false)
+	return nil
+}
+
+func (i *innerTran) GetAll(ctx context.Context, data []Pathable) ([]error, error) {
+	paths, refs, err := docRefs(ctx, i.client, data)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := i.tran.GetAll(refs)
+	if err != nil {
+		return nil, newOpError("GetAll", "", err)
 	}
-	return fmt.Errorf("not implement Pathable")
+	for idx, s := range snapshots {
+		i.remember(paths[idx], s)
+	}
+	return dataToAll(ctx, data, paths, snapshots), nil
 }
 
 func (i *innerTran) Delete(ctx context.Context, data any) error {
 	rv := reflect.ValueOf(data)
 	if p, ok := rv.Interface().(Pathable); ok {
-		return i.tran.Delete(i.client.Doc(p.Path(ctx)))
+		if err := BeforeDelete(ctx, data); err != nil {
+			return newOpError("Delete", p.Path(ctx), err)
+		}
+		path := p.Path(ctx)
+		if err := ValidatePath(p, path); err != nil {
+			return newOpError("Delete", path, err)
+		}
+		pre, err := i.precondition(path, data)
+		if err != nil {
+			return newOpError("Delete", path, err)
+		}
+		if err := i.tran.Delete(i.client.Doc(path), pre...); err != nil {
+			return newOpError("Delete", path, err)
+		}
+		i.wrote = true
+		i.track(data, true)
+		return nil
 	}
-	return fmt.Errorf("not implement Pathable")
+	return newOpError("Delete", "", ErrNotPathable)
 }
@@ -0,0 +1,113 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+// FieldUpdate is a single field change applied by Update.
+// Path is a dot-separated field path such as "profile.name".
+type FieldUpdate struct {
+	Path  string
+	Value any
+}
+
+// TransformKind identifies a server-side field transform.
+type TransformKind int
+
+const (
+	TransformServerTimestamp TransformKind = iota + 1
+	TransformDelete
+	TransformIncrement
+	TransformArrayUnion
+	TransformArrayRemove
+)
+
+// Transform is a sentinel value for FieldUpdate.Value that is resolved by
+// the backend instead of being stored as is.
+type Transform struct {
+	Kind   TransformKind
+	Values []any
+}
+
+// ServerTimestamp sets the field to the commit time of the write.
+var ServerTimestamp = Transform{Kind: TransformServerTimestamp}
+
+// DeleteField removes the field from the document.
+var DeleteField = Transform{Kind: TransformDelete}
+
+// Increment adds n to the numeric field.
+func Increment(n any) Transform {
+	return Transform{Kind: TransformIncrement, Values: []any{n}}
+}
+
+// ArrayUnion adds elements to the array field unless already present.
+func ArrayUnion(elems ...any) Transform {
+	return Transform{Kind: TransformArrayUnion, Values: elems}
+}
+
+// ArrayRemove removes all instances of elements from the array field.
+func ArrayRemove(elems ...any) Transform {
+	return Transform{Kind: TransformArrayRemove, Values: elems}
+}
+
+func toFirestoreUpdates(updates []FieldUpdate) ([]firestore.Update, error) {
+	ret := make([]firestore.Update, 0, len(updates))
+	for _, u := range updates {
+		v, err := toFirestoreValue(u.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cloudfirestore: field %s: %w", u.Path, err)
+		}
+		ret = append(ret, firestore.Update{
+			Path:  u.Path,
+			Value: v,
+		})
+	}
+	return ret, nil
+}
+
+func toFirestoreValue(v any) (any, error) {
+	t, ok := v.(Transform)
+	if !ok {
+		return v, nil
+	}
+	switch t.Kind {
+	case TransformServerTimestamp:
+		return firestore.ServerTimestamp, nil
+	case TransformDelete:
+		return firestore.Delete, nil
+	case TransformIncrement:
+		if len(t.Values) != 1 {
+			return nil, fmt.Errorf("increment needs exactly one value, got %d", len(t.Values))
+		}
+		return firestore.Increment(t.Values[0]), nil
+	case TransformArrayUnion:
+		return firestore.ArrayUnion(t.Values...), nil
+	case TransformArrayRemove:
+		return firestore.ArrayRemove(t.Values...), nil
+	}
+	return nil, fmt.Errorf("unknown transform %d", t.Kind)
+}
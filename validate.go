@@ -0,0 +1,329 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Validator is implemented by data that checks itself before Create and Set.
+// Returning a *ValidationError merges its fields with those of the struct
+// tags.
+type Validator interface {
+	Validate(context.Context) error
+}
+
+// FieldError is one failing rule of a field.
+type FieldError struct {
+	// Field is the Go field path such as "Address.City".
+	Field string
+	Rule  string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every failing field of the data.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for idx, f := range e.Fields {
+		msgs[idx] = f.Error()
+	}
+	return "cloudfirestore: validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Rule checks a value against the parameter written after "=" in the tag.
+type Rule func(v reflect.Value, param string) error
+
+var (
+	rulesMu sync.RWMutex
+	// rules are applied from the `validate:"..."` tag of struct fields,
+	// e.g. `validate:"required,min=1,max=64,enum=a|b,regex=^[a-z]+$"`.
+	// regex takes the rest of the tag, so it must come last.
+	rules = map[string]Rule{
+		"required": ruleRequired,
+		"min":      ruleMin,
+		"max":      ruleMax,
+		"regex":    ruleRegex,
+		"enum":     ruleEnum,
+	}
+	regexps sync.Map
+)
+
+// RegisterRule adds or replaces a rule usable in validate tags.
+func RegisterRule(name string, rule Rule) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	rules[name] = rule
+}
+
+// Validate runs the validate tags and the Validator of data, for backends
+// implementing the writes.
+func Validate(ctx context.Context, data any) error {
+	fields := validateStruct(reflect.ValueOf(data), "", nil)
+	if v, ok := data.(Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			var ve *ValidationError
+			if errors.As(err, &ve) {
+				fields = append(fields, ve.Fields...)
+			} else {
+				fields = append(fields, FieldError{Rule: "Validate", Err: err})
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// ValidateUpdates checks each updated value against the validate tag of the
+// field of data stored at its path. Transforms are not checked.
+func ValidateUpdates(data any, updates []FieldUpdate) error {
+	t := reflect.TypeOf(data)
+	var fields []FieldError
+	for _, u := range updates {
+		if _, ok := u.Value.(Transform); ok {
+			continue
+		}
+		f, name, ok := fieldByPath(t, u.Path)
+		if !ok {
+			continue
+		}
+		fields = applyRules(reflect.ValueOf(u.Value), f.Tag.Get("validate"), name, fields)
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: fields}
+}
+
+func validateStruct(rv reflect.Value, prefix string, fields []FieldError) []FieldError {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fields
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || rv.Type() == reflect.TypeOf(time.Time{}) {
+		return fields
+	}
+	for _, f := range reflect.VisibleFields(rv.Type()) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		// Fields promoted through a nil embedded pointer are skipped.
+		v, err := rv.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		name := prefix + f.Name
+		fields = applyRules(v, f.Tag.Get("validate"), name, fields)
+		fields = validateStruct(v, name+".", fields)
+	}
+	return fields
+}
+
+func applyRules(v reflect.Value, tag, field string, fields []FieldError) []FieldError {
+	if tag == "" || tag == "-" {
+		return fields
+	}
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	// Nil pointers and missing values only fail required.
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	empty := !v.IsValid() || v.IsZero()
+	for tag != "" {
+		var item string
+		if strings.HasPrefix(tag, "regex=") {
+			item, tag = tag, ""
+		} else {
+			item, tag, _ = strings.Cut(tag, ",")
+		}
+		name, param, _ := strings.Cut(item, "=")
+		switch {
+		case name == "omitempty":
+			if empty {
+				return fields
+			}
+			continue
+		case name != "required" && (!v.IsValid() || v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface):
+			continue
+		}
+		rule, ok := rules[name]
+		if !ok {
+			fields = append(fields, FieldError{Field: field, Rule: name, Err: fmt.Errorf("unknown rule %q", name)})
+			continue
+		}
+		if err := rule(v, param); err != nil {
+			fields = append(fields, FieldError{Field: field, Rule: name, Err: err})
+		}
+	}
+	return fields
+}
+
+// fieldByPath finds the struct field stored at the dot-separated Firestore
+// field path, and its Go field path.
+func fieldByPath(t reflect.Type, path string) (reflect.StructField, string, bool) {
+	var names []string
+	var found reflect.StructField
+	for _, key := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return found, "", false
+		}
+		ok := false
+		for _, f := range reflect.VisibleFields(t) {
+			if f.Anonymous || !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("firestore"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if name == key {
+				found, ok = f, true
+				break
+			}
+		}
+		if !ok {
+			return found, "", false
+		}
+		names = append(names, found.Name)
+		t = found.Type
+	}
+	return found, strings.Join(names, "."), true
+}
+
+func ruleRequired(v reflect.Value, _ string) error {
+	if !v.IsValid() || v.IsZero() {
+		return errors.New("is required")
+	}
+	return nil
+}
+
+// size is the number compared by min and max: the value of numbers and the
+// length of strings, slices and maps.
+func size(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), nil
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(v.Len()), nil
+	}
+	return 0, fmt.Errorf("cannot compare %s", v.Type())
+}
+
+func ruleMin(v reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid min %q", param)
+	}
+	n, err := size(v)
+	if err != nil {
+		return err
+	}
+	if n < limit {
+		return fmt.Errorf("must be at least %s", param)
+	}
+	return nil
+}
+
+func ruleMax(v reflect.Value, param string) error {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid max %q", param)
+	}
+	n, err := size(v)
+	if err != nil {
+		return err
+	}
+	if n > limit {
+		return fmt.Errorf("must be at most %s", param)
+	}
+	return nil
+}
+
+func ruleRegex(v reflect.Value, param string) error {
+	if v.Kind() != reflect.String {
+		return fmt.Errorf("cannot match %s", v.Type())
+	}
+	re, ok := regexps.Load(param)
+	if !ok {
+		compiled, err := regexp.Compile(param)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q", param)
+		}
+		re, _ = regexps.LoadOrStore(param, compiled)
+	}
+	if !re.(*regexp.Regexp).MatchString(v.String()) {
+		return fmt.Errorf("must match %s", param)
+	}
+	return nil
+}
+
+func ruleEnum(v reflect.Value, param string) error {
+	s := fmt.Sprint(v.Interface())
+	for _, allowed := range strings.Split(param, "|") {
+		if s == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.ReplaceAll(param, "|", ", "))
+}
@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Eigen438/cloudfirestore"
+	"github.com/Eigen438/cloudfirestore/memory"
+)
+
+type auditBase struct {
+	Owner string `validate:"required"`
+}
+
+type auditedDoc struct {
+	*auditBase
+	ID   string `firestore:"-"`
+	Name string `validate:"required"`
+}
+
+func (d *auditedDoc) Path(context.Context) string {
+	return "audited/" + d.ID
+}
+
+func TestValidateNilEmbedded(t *testing.T) {
+	ctx := context.Background()
+	if err := cloudfirestore.Validate(ctx, &auditedDoc{ID: "a", Name: "x"}); err != nil {
+		t.Errorf("Validate = %v, want nil", err)
+	}
+
+	var verr *cloudfirestore.ValidationError
+	err := cloudfirestore.Validate(ctx, &auditedDoc{ID: "a"})
+	if !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Field != "Name" {
+		t.Errorf("Validate = %v, want a Name error", err)
+	}
+	err = cloudfirestore.Validate(ctx, &auditedDoc{auditBase: &auditBase{}, ID: "a", Name: "x"})
+	if !errors.As(err, &verr) || len(verr.Fields) != 1 || verr.Fields[0].Field != "Owner" {
+		t.Errorf("Validate = %v, want an Owner error", err)
+	}
+
+	if err := memory.New().Set(ctx, &auditedDoc{ID: "a", Name: "x"}); err != nil {
+		t.Errorf("Set = %v, want nil", err)
+	}
+}
+
+func TestValidateBatchAndBulk(t *testing.T) {
+	ctx := context.Background()
+	backends := map[string]cloudfirestore.CloudFirestore{
+		"memory":    memory.New(),
+		"firestore": newFakeClient(t, newFakeFirestore(0)),
+	}
+	invalid := cloudfirestore.FieldUpdate{Path: "Name", Value: ""}
+	for name, c := range backends {
+		t.Run(name, func(t *testing.T) {
+			var verr *cloudfirestore.ValidationError
+			b := c.Batch()
+			if err := b.Create(ctx, &auditedDoc{ID: "a"}); !errors.As(err, &verr) {
+				t.Errorf("Batch Create = %v, want a ValidationError", err)
+			}
+			if err := b.Set(ctx, &auditedDoc{ID: "a"}); !errors.As(err, &verr) {
+				t.Errorf("Batch Set = %v, want a ValidationError", err)
+			}
+			if err := b.Update(ctx, &auditedDoc{ID: "a"}, invalid); !errors.As(err, &verr) {
+				t.Errorf("Batch Update = %v, want a ValidationError", err)
+			}
+
+			bw := c.Bulk(ctx)
+			if err := bw.Create(ctx, &auditedDoc{ID: "a"}); !errors.As(err, &verr) {
+				t.Errorf("Bulk Create = %v, want a ValidationError", err)
+			}
+			if err := bw.Set(ctx, &auditedDoc{ID: "a"}); !errors.As(err, &verr) {
+				t.Errorf("Bulk Set = %v, want a ValidationError", err)
+			}
+			if err := bw.Update(ctx, &auditedDoc{ID: "a"}, invalid); !errors.As(err, &verr) {
+				t.Errorf("Bulk Update = %v, want a ValidationError", err)
+			}
+			summary, err := bw.End(ctx)
+			if err != nil || summary.Succeeded+summary.Failed != 0 {
+				t.Errorf("End = %+v, %v, want no queued writes", summary, err)
+			}
+		})
+	}
+}
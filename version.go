@@ -0,0 +1,128 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Versioned is implemented by data written with optimistic concurrency: a
+// write succeeds only if the stored document was last updated at Version,
+// and fails with ErrConflict otherwise. A zero Version means the document
+// must not exist yet. Reads and writes keep the version up to date.
+//
+// Instead of implementing Versioned, a struct can tag a time.Time field with
+// `cloudfirestore:"version"`, usually together with `firestore:"-"`.
+type Versioned interface {
+	Version() time.Time
+	SetVersion(time.Time)
+}
+
+// versionField returns the index of the version field of a struct type, or
+// nil when it has none.
+func versionField(t reflect.Type) []int {
+	if f, ok := taggedFields(t)["version"]; ok && f.Type == timeType {
+		return f.Index
+	}
+	return nil
+}
+
+// VersionOf returns the version of data, and whether data is versioned.
+func VersionOf(data any) (time.Time, bool) {
+	if v, ok := data.(Versioned); ok {
+		return v.Version(), true
+	}
+	rv := reflect.Indirect(reflect.ValueOf(data))
+	if rv.Kind() != reflect.Struct {
+		return time.Time{}, false
+	}
+	index := versionField(rv.Type())
+	if index == nil {
+		return time.Time{}, false
+	}
+	// A nil embedded struct holds no version yet.
+	fv, err := rv.FieldByIndexErr(index)
+	if err != nil {
+		return time.Time{}, true
+	}
+	return fv.Interface().(time.Time), true
+}
+
+// SetVersion records t as the version of data, if data is versioned and
+// settable.
+func SetVersion(data any, t time.Time) {
+	if v, ok := data.(Versioned); ok {
+		v.SetVersion(t)
+		return
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	rv = rv.Elem()
+	if index := versionField(rv.Type()); index != nil {
+		if fv, ok := allocField(rv, index); ok {
+			fv.Set(reflect.ValueOf(t))
+		}
+	}
+}
+
+// preconditions returns the precondition for updating or deleting versioned
+// data.
+func preconditions(data any) []firestore.Precondition {
+	if v, ok := VersionOf(data); ok && !v.IsZero() {
+		return []firestore.Precondition{firestore.LastUpdateTime(v)}
+	}
+	return nil
+}
+
+// checkVersion compares the version of data with the stored document.
+func checkVersion(data any, s *firestore.DocumentSnapshot) error {
+	v, ok := VersionOf(data)
+	if !ok {
+		return nil
+	}
+	if v.IsZero() {
+		if s.Exists() {
+			return ErrConflict
+		}
+		return nil
+	}
+	if !s.Exists() || !s.UpdateTime.Equal(v) {
+		return ErrConflict
+	}
+	return nil
+}
+
+// conflict reports a failed update time precondition as ErrConflict.
+func conflict(err error) error {
+	if status.Code(err) == codes.FailedPrecondition {
+		return &kindError{kind: ErrConflict, err: err}
+	}
+	return err
+}
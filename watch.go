@@ -0,0 +1,215 @@
+// MIT License
+//
+// Copyright (c) 2025 Eigen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cloudfirestore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+// ChangeKind is the kind of change delivered to a watch handler.
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota + 1
+	ChangeModified
+	ChangeRemoved
+)
+
+// Change is a single document change seen by Watch or WatchQuery.
+type Change struct {
+	Kind     ChangeKind
+	Document *Document
+}
+
+const (
+	watchInitialDelay = time.Second
+	watchMaxDelay     = 32 * time.Second
+)
+
+// handlerError marks an error returned by the caller's handler, which ends
+// the watch instead of triggering a reconnect.
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string {
+	return e.err.Error()
+}
+
+// watchLoop runs listen until the handler fails, ctx is done or listen
+// returns an error that isn't worth reconnecting for. listen reports whether
+// it received a snapshot, which resets the backoff.
+func watchLoop(ctx context.Context, op, path string, listen func() (bool, error)) error {
+	delay := watchInitialDelay
+	for {
+		received, err := listen()
+		var he *handlerError
+		if errors.As(err, &he) {
+			return he.err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != iterator.Done && !IsRetryable(err) {
+			return newOpError(op, path, err)
+		}
+		if received {
+			delay = watchInitialDelay
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, watchMaxDelay)
+	}
+}
+
+// handleChanges delivers one snapshot's changes to f inside a single span.
+func handleChanges(ctx context.Context, name string, changes []Change, f func(context.Context, Change) error) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	ctx, span := tracer.Start(ctx, name)
+	defer span.End()
+
+	for _, c := range changes {
+		if err := f(ctx, c); err != nil {
+			span.RecordError(err)
+			return &handlerError{err: err}
+		}
+	}
+	return nil
+}
+
+func (i *inner) Watch(ctx context.Context, data Pathable, f func(context.Context, Change) error) error {
+	path := data.Path(ctx)
+	if err := ValidatePath(data, path); err != nil {
+		return newOpError("Watch", path, err)
+	}
+	ref := i.client.Doc(path)
+
+	var last *Document
+	return watchLoop(ctx, "Watch", path, func() (bool, error) {
+		iter := ref.Snapshots(ctx)
+		defer iter.Stop()
+
+		received := false
+		for {
+			s, err := iter.Next()
+			if err != nil {
+				return received, err
+			}
+			received = true
+
+			var change Change
+			switch {
+			case s.Exists() && last == nil:
+				change = Change{Kind: ChangeAdded, Document: newDocument(s)}
+			case s.Exists() && !s.UpdateTime.Equal(last.UpdateTime):
+				change = Change{Kind: ChangeModified, Document: newDocument(s)}
+			case !s.Exists() && last != nil:
+				change = Change{Kind: ChangeRemoved, Document: last}
+			default:
+				continue
+			}
+			if s.Exists() {
+				last = change.Document
+			} else {
+				last = nil
+			}
+			if err := handleChanges(ctx, "Watch/Process:"+path, []Change{change}, f); err != nil {
+				return received, err
+			}
+		}
+	})
+}
+
+func (i *inner) WatchQuery(ctx context.Context, q Query, f func(context.Context, Change) error) error {
+	fq := toFirestoreQuery(i.client, q)
+
+	// known holds the documents delivered so far, so a reconnect only
+	// reports what actually changed while the stream was down.
+	known := map[string]*Document{}
+	return watchLoop(ctx, "WatchQuery", q.Spec().Collection, func() (bool, error) {
+		iter := fq.Snapshots(ctx)
+		defer iter.Stop()
+
+		received := false
+		for {
+			qs, err := iter.Next()
+			if err != nil {
+				return received, err
+			}
+
+			var changes []Change
+			seen := map[string]bool{}
+			for _, c := range qs.Changes {
+				doc := newDocument(c.Doc)
+				seen[doc.Path] = true
+				kind := changeKind(c.Kind)
+				if !received && kind == ChangeAdded {
+					if prev, ok := known[doc.Path]; ok {
+						if prev.UpdateTime.Equal(doc.UpdateTime) {
+							continue
+						}
+						kind = ChangeModified
+					}
+				}
+				if kind == ChangeRemoved {
+					delete(known, doc.Path)
+				} else {
+					known[doc.Path] = doc
+				}
+				changes = append(changes, Change{Kind: kind, Document: doc})
+			}
+			if !received {
+				for path, doc := range known {
+					if !seen[path] {
+						delete(known, path)
+						changes = append(changes, Change{Kind: ChangeRemoved, Document: doc})
+					}
+				}
+			}
+			received = true
+			if err := handleChanges(ctx, "WatchQuery/Process", changes, f); err != nil {
+				return received, err
+			}
+		}
+	})
+}
+
+func changeKind(k firestore.DocumentChangeKind) ChangeKind {
+	switch k {
+	case firestore.DocumentAdded:
+		return ChangeAdded
+	case firestore.DocumentModified:
+		return ChangeModified
+	}
+	return ChangeRemoved
+}